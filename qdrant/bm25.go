@@ -0,0 +1,134 @@
+package qdrant
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer splits text into terms for client-side sparse encoding
+// The zero value lowercases text and keeps every word
+type Tokenizer struct {
+	PreserveCase bool
+	MinTokenLen  int
+	MaxTokenLen  int
+	Stopwords    map[string]struct{}
+}
+
+// NewStopwords creates a stopword set from a list of words
+func NewStopwords(words ...string) map[string]struct{} {
+	stopwords := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		stopwords[strings.ToLower(word)] = struct{}{}
+	}
+	return stopwords
+}
+
+// Tokenize splits text on anything that is not a letter or a digit and filters the resulting terms
+func (t *Tokenizer) Tokenize(text string) []string {
+	if !t.PreserveCase {
+		text = strings.ToLower(text)
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		length := utf8.RuneCountInString(word)
+		if t.MinTokenLen > 0 && length < t.MinTokenLen {
+			continue
+		}
+		if t.MaxTokenLen > 0 && length > t.MaxTokenLen {
+			continue
+		}
+		if _, ok := t.Stopwords[strings.ToLower(word)]; ok {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// TermIndex returns the stable sparse vector index of a term
+// It is the 32-bit FNV-1a hash of the term, so documents and queries encoded
+// by different processes agree on indices without sharing a vocabulary
+func TermIndex(term string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(term))
+	return h.Sum32()
+}
+
+// BM25Encoder turns text into sparse vectors weighted by BM25 term frequency
+// Only the term frequency part of BM25 is computed on the client; the vectors are
+// meant for a sparse vector field configured with ModifierIDF, which lets the
+// server apply inverse document frequency using collection-wide statistics
+type BM25Encoder struct {
+	Tokenizer Tokenizer
+	K1        float64
+	B         float64
+	AvgDocLen float64
+}
+
+// NewBM25Encoder creates an encoder with the usual BM25 parameters
+// avgDocLen is the average number of terms per document; when it is not
+// positive, document length normalization is disabled
+func NewBM25Encoder(avgDocLen float64) *BM25Encoder {
+	return &BM25Encoder{
+		K1:        1.2,
+		B:         0.75,
+		AvgDocLen: avgDocLen,
+	}
+}
+
+// EncodeDocument encodes a document, weighting each term by its saturated frequency
+func (e *BM25Encoder) EncodeDocument(text string) *SparseVector {
+	terms := e.Tokenizer.Tokenize(text)
+
+	counts := make(map[string]int, len(terms))
+	for _, term := range terms {
+		counts[term]++
+	}
+
+	norm := 1.0
+	if e.AvgDocLen > 0 {
+		norm = 1 - e.B + e.B*float64(len(terms))/e.AvgDocLen
+	}
+
+	weights := make(map[uint32]float32, len(counts))
+	for term, count := range counts {
+		tf := float64(count)
+		weights[TermIndex(term)] += float32(tf * (e.K1 + 1) / (tf + e.K1*norm))
+	}
+	return NewSparseVectorFromMap(weights)
+}
+
+// EncodeQuery encodes a query, giving every distinct term a weight of one
+func (e *BM25Encoder) EncodeQuery(text string) *SparseVector {
+	weights := make(map[uint32]float32)
+	for _, term := range e.Tokenizer.Tokenize(text) {
+		weights[TermIndex(term)] = 1
+	}
+	return NewSparseVectorFromMap(weights)
+}
+
+// EmbedDocuments encodes documents so the encoder can be used as an Embedder
+func (e *BM25Encoder) EmbedDocuments(ctx context.Context, texts []string) ([]*Vector, error) {
+	vectors := make([]*Vector, len(texts))
+	for i, text := range texts {
+		vectors[i] = NewVectorSparse(e.EncodeDocument(text))
+	}
+	return vectors, nil
+}
+
+// EmbedQueries encodes queries so the encoder can be used as an Embedder
+func (e *BM25Encoder) EmbedQueries(ctx context.Context, texts []string) ([]*Vector, error) {
+	vectors := make([]*Vector, len(texts))
+	for i, text := range texts {
+		vectors[i] = NewVectorSparse(e.EncodeQuery(text))
+	}
+	return vectors, nil
+}
@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 type Client struct {
-	client  *http.Client
-	baseURL string
-	apiKey  string
+	client   *http.Client
+	baseURL  string
+	apiKey   string
+	embedder Embedder
+
+	versionMu sync.Mutex
+	version   *VersionInfo
 }
 
 func NewClient(config *Config) (*Client, error) {
@@ -31,9 +36,10 @@ func NewClient(config *Config) (*Client, error) {
 	}
 
 	return &Client{
-		client:  config.getHTTPClient(),
-		baseURL: config.getBaseURL(),
-		apiKey:  config.APIKey,
+		client:   config.getHTTPClient(),
+		baseURL:  config.getBaseURL(),
+		apiKey:   config.APIKey,
+		embedder: config.Embedder,
 	}, nil
 }
 
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 // PeerState represents the state of a peer in the cluster
@@ -24,16 +28,19 @@ type ShardInfo struct {
 
 // LocalShardInfo represents information about a local shard
 type LocalShardInfo struct {
-	ShardID uint64 `json:"shard_id"`
-	State   string `json:"state"`
+	ShardID     uint64    `json:"shard_id"`
+	ShardKey    *ShardKey `json:"shard_key,omitempty"`
+	PointsCount uint64    `json:"points_count"`
+	State       string    `json:"state"`
 }
 
 // RemoteShardInfo represents information about a remote shard
 type RemoteShardInfo struct {
-	ShardID  uint64 `json:"shard_id"`
-	State    string `json:"state"`
-	PeerID   uint64 `json:"peer_id"`
-	PeerURI  string `json:"peer_uri"`
+	ShardID  uint64    `json:"shard_id"`
+	ShardKey *ShardKey `json:"shard_key,omitempty"`
+	State    string    `json:"state"`
+	PeerID   uint64    `json:"peer_id"`
+	PeerURI  string    `json:"peer_uri"`
 }
 
 // ReplicaSetShard represents a shard in a replica set
@@ -63,15 +70,37 @@ type CollectionClusterInfo struct {
 }
 
 // ShardTransfer represents information about a shard transfer
+// Sync is set when the source keeps its replica, as when replicating; Comment
+// describes the progress of the transfer
 type ShardTransfer struct {
-	ShardID       uint64 `json:"shard_id"`
-	FromPeerID    uint64 `json:"from_peer_id"`
-	ToPeerID      uint64 `json:"to_peer_id"`
-	FromPeerURI   string `json:"from_peer_uri,omitempty"`
-	ToPeerURI     string `json:"to_peer_uri,omitempty"`
-	SyncState     string `json:"sync_state,omitempty"`
+	ShardID    uint64              `json:"shard_id"`
+	ToShardID  *uint64             `json:"to_shard_id,omitempty"`
+	FromPeerID uint64              `json:"from"`
+	ToPeerID   uint64              `json:"to"`
+	Sync       bool                `json:"sync"`
+	Method     ShardTransferMethod `json:"method,omitempty"`
+	Comment    string              `json:"comment,omitempty"`
 }
 
+// ShardTransferMethod selects how the data of a shard is transferred between peers
+// Snapshot transfers are faster for large shards, and wal_delta only sends the
+// operations a recovering replica missed
+type ShardTransferMethod string
+
+const (
+	ShardTransferMethodStreamRecords ShardTransferMethod = "stream_records"
+	ShardTransferMethodSnapshot      ShardTransferMethod = "snapshot"
+	ShardTransferMethodWALDelta      ShardTransferMethod = "wal_delta"
+)
+
+// ReshardingDirection selects whether resharding adds or removes a shard
+type ReshardingDirection string
+
+const (
+	ReshardingDirectionUp   ReshardingDirection = "up"
+	ReshardingDirectionDown ReshardingDirection = "down"
+)
+
 // ClusterInfo represents the overall cluster information
 type ClusterInfo struct {
 	PeerID                  uint64                   `json:"peer_id"`
@@ -96,25 +125,88 @@ type GetCollectionClusterInfoResponse struct {
 }
 
 // UpdateCollectionClusterSetupRequest represents the request body for updating cluster setup
+// Exactly one operation must be set
 type UpdateCollectionClusterSetupRequest struct {
 	MoveShard       *MoveShardRequest       `json:"move_shard,omitempty"`
 	ReplicateShard  *ReplicateShardRequest  `json:"replicate_shard,omitempty"`
 	DropReplica     *DropReplicaRequest     `json:"drop_replica,omitempty"`
 	AbortTransfer   *AbortTransferRequest   `json:"abort_transfer,omitempty"`
 	RestartTransfer *RestartTransferRequest `json:"restart_transfer,omitempty"`
+	ReplicatePoints *ReplicatePointsRequest `json:"replicate_points,omitempty"`
+	StartResharding *StartReshardingRequest `json:"start_resharding,omitempty"`
+	AbortResharding *AbortReshardingRequest `json:"abort_resharding,omitempty"`
+}
+
+// validate checks that exactly one operation is set and that it is well formed
+func (r *UpdateCollectionClusterSetupRequest) validate() error {
+	operations := 0
+	for _, set := range []bool{
+		r.MoveShard != nil,
+		r.ReplicateShard != nil,
+		r.DropReplica != nil,
+		r.AbortTransfer != nil,
+		r.RestartTransfer != nil,
+		r.ReplicatePoints != nil,
+		r.StartResharding != nil,
+		r.AbortResharding != nil,
+	} {
+		if set {
+			operations++
+		}
+	}
+	if operations != 1 {
+		return fmt.Errorf("exactly one operation must be set, got %d", operations)
+	}
+
+	switch {
+	case r.MoveShard != nil:
+		return validateTransferMethod(r.MoveShard.Method)
+	case r.ReplicateShard != nil:
+		return validateTransferMethod(r.ReplicateShard.Method)
+	case r.RestartTransfer != nil:
+		if r.RestartTransfer.Method == "" {
+			return fmt.Errorf("restarting a transfer requires a method")
+		}
+		return validateTransferMethod(r.RestartTransfer.Method)
+	case r.ReplicatePoints != nil:
+		return r.ReplicatePoints.Filter.validate()
+	case r.StartResharding != nil:
+		switch r.StartResharding.Direction {
+		case ReshardingDirectionUp, ReshardingDirectionDown:
+		default:
+			return fmt.Errorf("unsupported resharding direction %q", r.StartResharding.Direction)
+		}
+	}
+	return nil
+}
+
+// validateTransferMethod checks an optional shard transfer method
+func validateTransferMethod(method ShardTransferMethod) error {
+	switch method {
+	case "", ShardTransferMethodStreamRecords, ShardTransferMethodSnapshot, ShardTransferMethodWALDelta:
+		return nil
+	default:
+		return fmt.Errorf("unsupported transfer method %q", method)
+	}
 }
 
 // MoveShardRequest represents a request to move a shard
+// ToShardID moves the data into another shard, as used by resharding
 type MoveShardRequest struct {
-	ShardID    uint64 `json:"shard_id"`
-	FromPeerID uint64 `json:"from_peer_id"`
-	ToPeerID   uint64 `json:"to_peer_id"`
+	ShardID    uint64              `json:"shard_id"`
+	ToShardID  *uint64             `json:"to_shard_id,omitempty"`
+	FromPeerID uint64              `json:"from_peer_id"`
+	ToPeerID   uint64              `json:"to_peer_id"`
+	Method     ShardTransferMethod `json:"method,omitempty"`
 }
 
 // ReplicateShardRequest represents a request to replicate a shard
 type ReplicateShardRequest struct {
-	ShardID  uint64 `json:"shard_id"`
-	PeerID   uint64 `json:"peer_id"`
+	ShardID    uint64              `json:"shard_id"`
+	ToShardID  *uint64             `json:"to_shard_id,omitempty"`
+	FromPeerID uint64              `json:"from_peer_id"`
+	ToPeerID   uint64              `json:"to_peer_id"`
+	Method     ShardTransferMethod `json:"method,omitempty"`
 }
 
 // DropReplicaRequest represents a request to drop a replica
@@ -125,18 +217,40 @@ type DropReplicaRequest struct {
 
 // AbortTransferRequest represents a request to abort a shard transfer
 type AbortTransferRequest struct {
-	ShardID    uint64 `json:"shard_id"`
-	FromPeerID uint64 `json:"from_peer_id"`
-	ToPeerID   uint64 `json:"to_peer_id"`
+	ShardID    uint64  `json:"shard_id"`
+	ToShardID  *uint64 `json:"to_shard_id,omitempty"`
+	FromPeerID uint64  `json:"from_peer_id"`
+	ToPeerID   uint64  `json:"to_peer_id"`
 }
 
-// RestartTransferRequest represents a request to restart a shard transfer
+// RestartTransferRequest represents a request to restart a shard transfer with another method
 type RestartTransferRequest struct {
-	ShardID    uint64 `json:"shard_id"`
-	FromPeerID uint64 `json:"from_peer_id"`
-	ToPeerID   uint64 `json:"to_peer_id"`
+	ShardID    uint64              `json:"shard_id"`
+	ToShardID  *uint64             `json:"to_shard_id,omitempty"`
+	FromPeerID uint64              `json:"from_peer_id"`
+	ToPeerID   uint64              `json:"to_peer_id"`
+	Method     ShardTransferMethod `json:"method"`
+}
+
+// ReplicatePointsRequest represents a request to copy the points matching a filter
+// from the shards of one shard key to those of another
+type ReplicatePointsRequest struct {
+	FromShardKey ShardKey `json:"from_shard_key"`
+	ToShardKey   ShardKey `json:"to_shard_key"`
+	Filter       *Filter  `json:"filter,omitempty"`
 }
 
+// StartReshardingRequest represents a request to add or remove a shard of a collection
+// PeerID selects the peer of a new shard and ShardKey the shard key it belongs to
+type StartReshardingRequest struct {
+	Direction ReshardingDirection `json:"direction"`
+	PeerID    *uint64             `json:"peer_id,omitempty"`
+	ShardKey  *ShardKey           `json:"shard_key,omitempty"`
+}
+
+// AbortReshardingRequest represents a request to abort the ongoing resharding
+type AbortReshardingRequest struct{}
+
 // UpdateCollectionClusterSetupResponse represents the response from updating cluster setup
 type UpdateCollectionClusterSetupResponse struct {
 	Time   float64 `json:"time"`
@@ -204,6 +318,10 @@ func (c *Client) GetCollectionClusterInfo(ctx context.Context, collectionName st
 func (c *Client) UpdateCollectionClusterSetup(ctx context.Context, collectionName string, request *UpdateCollectionClusterSetupRequest) (*UpdateCollectionClusterSetupResponse, error) {
 	path := fmt.Sprintf("/collections/%s/cluster", collectionName)
 
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request body: %w", err)
@@ -247,12 +365,26 @@ func (c *Client) MoveShard(ctx context.Context, collectionName string, shardID u
 	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
 }
 
-// ReplicateShard creates a replica of a shard on a specified peer
-func (c *Client) ReplicateShard(ctx context.Context, collectionName string, shardID uint64, peerID uint64) (*UpdateCollectionClusterSetupResponse, error) {
+// MoveShardWithMethod moves a shard from one peer to another using the given transfer method
+func (c *Client) MoveShardWithMethod(ctx context.Context, collectionName string, shardID uint64, fromPeerID uint64, toPeerID uint64, method ShardTransferMethod) (*UpdateCollectionClusterSetupResponse, error) {
+	request := &UpdateCollectionClusterSetupRequest{
+		MoveShard: &MoveShardRequest{
+			ShardID:    shardID,
+			FromPeerID: fromPeerID,
+			ToPeerID:   toPeerID,
+			Method:     method,
+		},
+	}
+	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
+}
+
+// ReplicateShard creates a replica of a shard on a specified peer, copying it from another peer
+func (c *Client) ReplicateShard(ctx context.Context, collectionName string, shardID uint64, fromPeerID uint64, toPeerID uint64) (*UpdateCollectionClusterSetupResponse, error) {
 	request := &UpdateCollectionClusterSetupRequest{
 		ReplicateShard: &ReplicateShardRequest{
-			ShardID: shardID,
-			PeerID:  peerID,
+			ShardID:    shardID,
+			FromPeerID: fromPeerID,
+			ToPeerID:   toPeerID,
 		},
 	}
 	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
@@ -281,14 +413,125 @@ func (c *Client) AbortTransfer(ctx context.Context, collectionName string, shard
 	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
 }
 
-// RestartTransfer restarts a shard transfer
-func (c *Client) RestartTransfer(ctx context.Context, collectionName string, shardID uint64, fromPeerID uint64, toPeerID uint64) (*UpdateCollectionClusterSetupResponse, error) {
+// RestartTransfer restarts a shard transfer using the given method
+func (c *Client) RestartTransfer(ctx context.Context, collectionName string, shardID uint64, fromPeerID uint64, toPeerID uint64, method ShardTransferMethod) (*UpdateCollectionClusterSetupResponse, error) {
 	request := &UpdateCollectionClusterSetupRequest{
 		RestartTransfer: &RestartTransferRequest{
 			ShardID:    shardID,
 			FromPeerID: fromPeerID,
 			ToPeerID:   toPeerID,
+			Method:     method,
+		},
+	}
+	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
+}
+
+// ReplicatePoints copies the points matching the filter from the shards of one shard key
+// to those of another, for example to move a growing tenant to a dedicated shard key
+func (c *Client) ReplicatePoints(ctx context.Context, collectionName string, fromShardKey ShardKey, toShardKey ShardKey, filter *Filter) (*UpdateCollectionClusterSetupResponse, error) {
+	request := &UpdateCollectionClusterSetupRequest{
+		ReplicatePoints: &ReplicatePointsRequest{
+			FromShardKey: fromShardKey,
+			ToShardKey:   toShardKey,
+			Filter:       filter,
 		},
 	}
 	return c.UpdateCollectionClusterSetup(ctx, collectionName, request)
-}
\ No newline at end of file
+}
+
+// StartResharding starts adding a shard to or removing a shard from a collection
+func (c *Client) StartResharding(ctx context.Context, collectionName string, request *StartReshardingRequest) (*UpdateCollectionClusterSetupResponse, error) {
+	return c.UpdateCollectionClusterSetup(ctx, collectionName, &UpdateCollectionClusterSetupRequest{
+		StartResharding: request,
+	})
+}
+
+// AbortResharding aborts the ongoing resharding of a collection
+func (c *Client) AbortResharding(ctx context.Context, collectionName string) (*UpdateCollectionClusterSetupResponse, error) {
+	return c.UpdateCollectionClusterSetup(ctx, collectionName, &UpdateCollectionClusterSetupRequest{
+		AbortResharding: &AbortReshardingRequest{},
+	})
+}
+
+// RemovePeerResponse represents the response from removing a peer
+type RemovePeerResponse struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result bool    `json:"result"`
+}
+
+// RecoverClusterResponse represents the response from recovering the cluster state
+type RecoverClusterResponse struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result bool    `json:"result"`
+}
+
+// RemovePeer removes a peer from the cluster
+// Without force the server refuses to remove a peer that still holds shards and a
+// *PeerHasShardsError is returned; with force its shards are dropped, which loses data
+// that has no other replica. A zero timeout uses the server default
+func (c *Client) RemovePeer(ctx context.Context, peerID uint64, force bool, timeout time.Duration) (*RemovePeerResponse, error) {
+	params := url.Values{}
+	if force {
+		params.Set("force", "true")
+	}
+	if timeout > 0 {
+		params.Set("timeout", strconv.FormatInt(int64(math.Ceil(timeout.Seconds())), 10))
+	}
+
+	path := fmt.Sprintf("/cluster/peer/%d", peerID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, clusterError(newAPIError(resp), peerID)
+	}
+
+	var response RemovePeerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// RecoverCluster asks the peer to recover its consensus state from the rest of the cluster
+// Failures are returned as an *APIError, or a *PeerHasShardsError when a peer still holds shards
+func (c *Client) RecoverCluster(ctx context.Context) (*RecoverClusterResponse, error) {
+	path := "/cluster/recover"
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, clusterError(newAPIError(resp), 0)
+	}
+
+	var response RecoverClusterResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
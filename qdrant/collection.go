@@ -29,9 +29,9 @@ type UsageHardware struct {
 	VectorIOWrite    int `json:"vector_io_write"`
 }
 
-// UsageInference represents inference usage information
+// UsageInference represents inference usage information, keyed by model name
 type UsageInference struct {
-	Models map[string]interface{} `json:"models"`
+	Models map[string]ModelUsage `json:"models"`
 }
 
 // Usage represents the usage information in the response
@@ -96,6 +96,7 @@ type CollectionDetails struct {
 	AutoMigrate           *bool                   `json:"auto_migrate,omitempty"`
 	RAMUsage              uint64                  `json:"ram_usage,omitempty"`
 	DiskUsage             uint64                  `json:"disk_usage,omitempty"`
+	Config                *CollectionConfig       `json:"config,omitempty"`
 }
 
 // GetCollection returns information about a specific collection
@@ -125,3 +126,190 @@ func (c *Client) GetCollection(ctx context.Context, collectionName string) (*Col
 
 	return &response, nil
 }
+
+// Distance represents the distance function used to compare vectors
+type Distance string
+
+const (
+	DistanceCosine    Distance = "Cosine"
+	DistanceEuclid    Distance = "Euclid"
+	DistanceDot       Distance = "Dot"
+	DistanceManhattan Distance = "Manhattan"
+)
+
+// MultiVectorComparator selects how sub-vectors of two multivectors are compared
+type MultiVectorComparator string
+
+const (
+	MultiVectorComparatorMaxSim MultiVectorComparator = "max_sim"
+)
+
+// MultiVectorConfig turns a vector field into a multivector field, where each
+// point stores several sub-vectors of the configured size
+type MultiVectorConfig struct {
+	Comparator MultiVectorComparator `json:"comparator"`
+}
+
+// VectorParams represents the configuration of a dense vector field
+type VectorParams struct {
+	Size              uint64             `json:"size"`
+	Distance          Distance           `json:"distance"`
+	OnDisk            *bool              `json:"on_disk,omitempty"`
+	MultivectorConfig *MultiVectorConfig `json:"multivector_config,omitempty"`
+}
+
+// VectorsConfig represents the configuration of either a single unnamed vector or several named vectors
+type VectorsConfig struct {
+	Params *VectorParams
+	Named  map[string]VectorParams
+}
+
+// MarshalJSON encodes the configuration as a single params object or a map of named params
+func (v VectorsConfig) MarshalJSON() ([]byte, error) {
+	if v.Params != nil {
+		return json.Marshal(v.Params)
+	}
+	return json.Marshal(v.Named)
+}
+
+// UnmarshalJSON decodes either a single params object or a map of named params
+func (v *VectorsConfig) UnmarshalJSON(data []byte) error {
+	*v = VectorsConfig{}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	if _, ok := probe["size"]; ok {
+		v.Params = &VectorParams{}
+		return json.Unmarshal(data, v.Params)
+	}
+	return json.Unmarshal(data, &v.Named)
+}
+
+// Modifier represents a transformation applied to sparse vector values at query time
+type Modifier string
+
+const (
+	ModifierNone Modifier = "none"
+	ModifierIDF  Modifier = "idf"
+)
+
+// SparseIndexParams represents the configuration of a sparse vector index
+type SparseIndexParams struct {
+	FullScanThreshold *uint64 `json:"full_scan_threshold,omitempty"`
+	OnDisk            *bool   `json:"on_disk,omitempty"`
+	Datatype          string  `json:"datatype,omitempty"`
+}
+
+// SparseVectorParams represents the configuration of a sparse vector field
+type SparseVectorParams struct {
+	Index    *SparseIndexParams `json:"index,omitempty"`
+	Modifier Modifier           `json:"modifier,omitempty"`
+}
+
+// CollectionParams represents the parameters a collection was created with
+type CollectionParams struct {
+	Vectors                VectorsConfig                 `json:"vectors"`
+	SparseVectors          map[string]SparseVectorParams `json:"sparse_vectors,omitempty"`
+	ShardNumber            uint32                        `json:"shard_number"`
+	ShardingMethod         ShardingMethod                `json:"sharding_method,omitempty"`
+	ReplicationFactor      uint32                        `json:"replication_factor"`
+	WriteConsistencyFactor uint32                        `json:"write_consistency_factor"`
+	OnDiskPayload          bool                          `json:"on_disk_payload"`
+}
+
+// CollectionConfig represents the configuration of a collection
+type CollectionConfig struct {
+	Params CollectionParams `json:"params"`
+}
+
+// CreateCollectionRequest represents the request body for creating a collection
+// With custom sharding ShardNumber is the number of shards created for each shard key
+type CreateCollectionRequest struct {
+	Vectors                *VectorsConfig                `json:"vectors,omitempty"`
+	SparseVectors          map[string]SparseVectorParams `json:"sparse_vectors,omitempty"`
+	ShardNumber            *uint32                       `json:"shard_number,omitempty"`
+	ShardingMethod         ShardingMethod                `json:"sharding_method,omitempty"`
+	ReplicationFactor      *uint32                       `json:"replication_factor,omitempty"`
+	WriteConsistencyFactor *uint32                       `json:"write_consistency_factor,omitempty"`
+	OnDiskPayload          *bool                         `json:"on_disk_payload,omitempty"`
+}
+
+// CreateCollectionResponse represents the response from creating a collection
+type CreateCollectionResponse struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result bool    `json:"result"`
+}
+
+// DeleteCollectionResponse represents the response from deleting a collection
+type DeleteCollectionResponse struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result bool    `json:"result"`
+}
+
+// CreateCollection creates a new collection with the given configuration
+func (c *Client) CreateCollection(ctx context.Context, collectionName string, request *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	path := fmt.Sprintf("/collections/%s", collectionName)
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response CreateCollectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteCollection deletes a collection and all of its data
+func (c *Client) DeleteCollection(ctx context.Context, collectionName string) (*DeleteCollectionResponse, error) {
+	path := fmt.Sprintf("/collections/%s", collectionName)
+
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response DeleteCollectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -15,6 +15,7 @@ type Config struct {
 	TLSConfig        *tls.Config
 	KeepAliveTime    int
 	KeepAliveTimeout uint
+	Embedder         Embedder
 }
 
 const (
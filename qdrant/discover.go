@@ -0,0 +1,192 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ContextPair represents a positive/negative pair of examples that splits the space
+// Points closer to the positive example than to the negative one are preferred
+type ContextPair struct {
+	Positive VectorInput `json:"positive"`
+	Negative VectorInput `json:"negative"`
+}
+
+// validate checks both examples of the pair
+func (p *ContextPair) validate() error {
+	if err := p.Positive.validate(); err != nil {
+		return fmt.Errorf("positive: %w", err)
+	}
+	if err := p.Negative.validate(); err != nil {
+		return fmt.Errorf("negative: %w", err)
+	}
+	return nil
+}
+
+// validateContext checks every pair of a context
+func validateContext(pairs []ContextPair) error {
+	for i := range pairs {
+		if err := pairs[i].validate(); err != nil {
+			return fmt.Errorf("context pair %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// DiscoverRequest represents the request body for discovery and context search
+// When Target is nil the request performs a context-only search
+type DiscoverRequest struct {
+	Target      *VectorInput     `json:"target,omitempty"`
+	Context     []ContextPair    `json:"context,omitempty"`
+	Filter      *Filter          `json:"filter,omitempty"`
+	Params      *SearchParams    `json:"params,omitempty"`
+	Limit       uint64           `json:"limit"`
+	Offset      uint64           `json:"offset,omitempty"`
+	WithPayload *PayloadSelector `json:"with_payload,omitempty"`
+	WithVector  *VectorSelector  `json:"with_vector,omitempty"`
+	Using       string           `json:"using,omitempty"`
+	LookupFrom  *LookupLocation  `json:"lookup_from,omitempty"`
+}
+
+// validate checks the request before it is sent
+func (r *DiscoverRequest) validate() error {
+	if err := r.Target.validate(); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return validateContext(r.Context)
+}
+
+// DiscoverBatchRequest represents the request body for a batch of discovery searches
+type DiscoverBatchRequest struct {
+	Searches []DiscoverRequest `json:"searches"`
+}
+
+// validate checks every search of the batch before it is sent
+func (r *DiscoverBatchRequest) validate() error {
+	for i := range r.Searches {
+		if err := r.Searches[i].validate(); err != nil {
+			return fmt.Errorf("search %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// DiscoverResponse represents the response from a discovery search
+type DiscoverResponse struct {
+	Usage  *Usage        `json:"usage"`
+	Time   float64       `json:"time"`
+	Status string        `json:"status"`
+	Result []ScoredPoint `json:"result"`
+}
+
+// DiscoverBatchResponse represents the response from a batch of discovery searches
+type DiscoverBatchResponse struct {
+	Usage  *Usage          `json:"usage"`
+	Time   float64         `json:"time"`
+	Status string          `json:"status"`
+	Result [][]ScoredPoint `json:"result"`
+}
+
+// Discover searches for points closest to the target within the space constrained by the context pairs
+func (c *Client) Discover(ctx context.Context, collectionName string, request *DiscoverRequest) (*DiscoverResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/discover", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response DiscoverResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DiscoverBatch runs multiple discovery searches in a single request
+func (c *Client) DiscoverBatch(ctx context.Context, collectionName string, request *DiscoverBatchRequest) (*DiscoverBatchResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/discover/batch", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response DiscoverBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ContextSearch searches for points that best satisfy the context pairs, without a target
+func (c *Client) ContextSearch(ctx context.Context, collectionName string, request *DiscoverRequest) (*DiscoverResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("request is required")
+	}
+
+	if request.Target != nil {
+		return nil, fmt.Errorf("context search does not accept a target")
+	}
+
+	if len(request.Context) == 0 {
+		return nil, fmt.Errorf("context search requires at least one context pair")
+	}
+
+	return c.Discover(ctx, collectionName, request)
+}
@@ -0,0 +1,223 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// CollectionShard identifies a shard of a collection
+type CollectionShard struct {
+	Collection string
+	ShardID    uint64
+}
+
+// DrainOptions controls how a peer is drained
+type DrainOptions struct {
+	// RemovePeer removes the peer from the cluster once it holds no replicas
+	RemovePeer bool
+	// Method is the transfer method of the moves, the server default when empty
+	Method ShardTransferMethod
+	// PollInterval is how often transfers are polled, one second when unset
+	PollInterval time.Duration
+	// Output receives the progress of the drain, discarded when nil
+	Output io.Writer
+}
+
+// DrainResult describes what a drain did
+type DrainResult struct {
+	Dropped     []CollectionShard
+	Moved       []ShardMove
+	PeerRemoved bool
+}
+
+// DrainPeer moves every shard replica off a peer so it can be decommissioned
+// A replica is dropped when another peer holds an active replica of the same shard,
+// otherwise it is moved to the peer with the fewest replicas that has none of that shard.
+// The drain is resumable: it waits for transfers involving the peer that are still
+// running, for example from an interrupted drain, and only acts on the replicas that
+// are left. Replicas that cannot be drained are reported in the returned error, and the
+// peer is only removed when every replica is gone. A peer that is not part of the
+// cluster, for example because an earlier drain removed it, is already drained
+func (c *Client) DrainPeer(ctx context.Context, peerID uint64, options *DrainOptions) (*DrainResult, error) {
+	if options == nil {
+		options = &DrainOptions{}
+	}
+
+	output := options.Output
+	if output == nil {
+		output = io.Discard
+	}
+
+	peers, err := c.clusterPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !peers[peerID] {
+		// A drain that removed the peer has nothing left to do when run again
+		fmt.Fprintf(output, "peer %d is not part of the cluster, nothing to drain\n", peerID)
+		return &DrainResult{}, nil
+	}
+
+	load := make(map[uint64]int)
+	for id := range peers {
+		if id != peerID {
+			load[id] = 0
+		}
+	}
+
+	collections, err := c.collectionNames(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(collections)
+
+	infos := make(map[string]*CollectionClusterInfo, len(collections))
+	for _, collection := range collections {
+		info, err := c.settleTransfers(ctx, collection, peerID, options.PollInterval, output)
+		if err != nil {
+			return nil, err
+		}
+		infos[collection] = info
+
+		for _, replica := range info.Replicas() {
+			if _, ok := load[replica.PeerID]; ok {
+				load[replica.PeerID]++
+			}
+		}
+	}
+
+	result := &DrainResult{}
+	var errs []error
+
+	for _, collection := range collections {
+		replicas := infos[collection].Replicas()
+
+		for _, replica := range replicas {
+			if replica.PeerID != peerID {
+				continue
+			}
+			shard := CollectionShard{Collection: collection, ShardID: replica.ShardID}
+
+			holders := make(map[uint64]bool)
+			healthyElsewhere := false
+			for _, other := range replicas {
+				if other.ShardID != replica.ShardID {
+					continue
+				}
+				holders[other.PeerID] = true
+				if other.PeerID != peerID && other.State == ReplicaStateActive {
+					healthyElsewhere = true
+				}
+			}
+
+			if healthyElsewhere {
+				fmt.Fprintf(output, "dropping shard %d of %q from peer %d\n", shard.ShardID, collection, peerID)
+				if _, err := c.DropReplica(ctx, collection, shard.ShardID, peerID); err != nil {
+					errs = append(errs, fmt.Errorf("dropping shard %d of %q: %w", shard.ShardID, collection, err))
+					continue
+				}
+				result.Dropped = append(result.Dropped, shard)
+				continue
+			}
+
+			if replica.State != ReplicaStateActive {
+				errs = append(errs, fmt.Errorf("shard %d of %q has no active replica to move, its replica on peer %d is %s", shard.ShardID, collection, peerID, replica.State))
+				continue
+			}
+
+			target, ok := leastLoadedPeer(load, holders)
+			if !ok {
+				errs = append(errs, fmt.Errorf("shard %d of %q has no peer to move to", shard.ShardID, collection))
+				continue
+			}
+			load[target]++
+
+			move := ShardMove{Collection: collection, ShardID: shard.ShardID, FromPeerID: peerID, ToPeerID: target}
+			if replica.PointsCount != nil {
+				move.Points = *replica.PointsCount
+			}
+			result.Moved = append(result.Moved, move)
+		}
+	}
+
+	// Moves all leave the drained peer, so the rebalancer runs them one at a time
+	rebalancer := NewRebalancer(c, RebalanceOptions{
+		Method:       options.Method,
+		PollInterval: options.PollInterval,
+		Output:       output,
+	})
+	if err := rebalancer.Execute(ctx, &RebalancePlan{Moves: result.Moved}); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return result, errors.Join(errs...)
+	}
+
+	if options.RemovePeer {
+		fmt.Fprintf(output, "removing peer %d\n", peerID)
+		if _, err := c.RemovePeer(ctx, peerID, false, 0); err != nil {
+			return result, fmt.Errorf("removing peer %d: %w", peerID, err)
+		}
+		result.PeerRemoved = true
+	}
+
+	fmt.Fprintf(output, "drained peer %d: %d replicas dropped, %d moved\n", peerID, len(result.Dropped), len(result.Moved))
+	return result, nil
+}
+
+// settleTransfers waits for the transfers of a collection that involve the peer and
+// returns the cluster info once none is left
+func (c *Client) settleTransfers(ctx context.Context, collection string, peerID uint64, pollInterval time.Duration, output io.Writer) (*CollectionClusterInfo, error) {
+	for {
+		response, err := c.GetCollectionClusterInfo(ctx, collection)
+		if err != nil {
+			return nil, fmt.Errorf("getting cluster info of %q: %w", collection, err)
+		}
+		info := &response.Result
+
+		var transfer *ShardTransfer
+		for i := range info.ShardTransfers {
+			if info.ShardTransfers[i].FromPeerID == peerID || info.ShardTransfers[i].ToPeerID == peerID {
+				transfer = &info.ShardTransfers[i]
+				break
+			}
+		}
+		if transfer == nil {
+			return info, nil
+		}
+
+		fmt.Fprintf(output, "waiting for transfer of shard %d of %q from peer %d to peer %d\n", transfer.ShardID, collection, transfer.FromPeerID, transfer.ToPeerID)
+		result, err := c.WaitForTransfer(ctx, collection, transfer.ShardID, transfer.FromPeerID, transfer.ToPeerID, &WaitForTransferOptions{
+			PollInterval: pollInterval,
+			OnProgress: func(progress TransferProgress) {
+				if progress.Total > 0 {
+					fmt.Fprintf(output, "transfer of shard %d of %q: %d/%d\n", transfer.ShardID, collection, progress.Done, progress.Total)
+				}
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("waiting for transfer of shard %d of %q: %w", transfer.ShardID, collection, err)
+		}
+		fmt.Fprintf(output, "transfer of shard %d of %q %s\n", transfer.ShardID, collection, result.Outcome)
+	}
+}
+
+// leastLoadedPeer returns the peer with the fewest replicas among those not in exclude
+func leastLoadedPeer(load map[uint64]int, exclude map[uint64]bool) (uint64, bool) {
+	var best uint64
+	found := false
+	for peerID, count := range load {
+		if exclude[peerID] {
+			continue
+		}
+		if !found || count < load[best] || (count == load[best] && peerID < best) {
+			best, found = peerID, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,162 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Embedder turns batches of texts into dense or sparse vectors on the client side
+// Documents and queries are embedded separately because many models encode them differently
+type Embedder interface {
+	EmbedDocuments(ctx context.Context, texts []string) ([]*Vector, error)
+	EmbedQueries(ctx context.Context, texts []string) ([]*Vector, error)
+}
+
+// TextPoint represents a point whose vector is computed from its text by the embedder
+type TextPoint struct {
+	ID      PointID
+	Text    string
+	Payload map[string]interface{}
+}
+
+// embed runs the embedder and checks that it returned one vector per text
+func embed(ctx context.Context, embedder Embedder, texts []string, query bool) ([]*Vector, error) {
+	if embedder == nil {
+		return nil, fmt.Errorf("no embedder configured")
+	}
+
+	var vectors []*Vector
+	var err error
+	if query {
+		vectors, err = embedder.EmbedQueries(ctx, texts)
+	} else {
+		vectors, err = embedder.EmbedDocuments(ctx, texts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("embedding texts: %w", err)
+	}
+
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vectors), len(texts))
+	}
+
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("embedder returned a nil vector for text %d", i)
+		}
+	}
+
+	return vectors, nil
+}
+
+// QueryText embeds the text with the configured embedder and runs it as a nearest
+// neighbours query, using the other fields of the request as they are
+func (c *Client) QueryText(ctx context.Context, collectionName string, text string, request *QueryRequest) (*QueryResponse, error) {
+	vectors, err := embed(ctx, c.embedder, []string{text}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	query := QueryRequest{}
+	if request != nil {
+		query = *request
+	}
+	query.Query = NewQueryNearest(vectors[0].toInput())
+
+	return c.Query(ctx, collectionName, &query)
+}
+
+// UpsertTexts embeds the texts of the points with the configured embedder and upserts them
+// The vectors are stored under vectorName, or as the unnamed vector when it is empty
+func (c *Client) UpsertTexts(ctx context.Context, collectionName string, vectorName string, points []TextPoint, options WriteOptions) (*UpsertPointsResponse, error) {
+	texts := make([]string, len(points))
+	for i, point := range points {
+		texts[i] = point.Text
+	}
+
+	vectors, err := embed(ctx, c.embedder, texts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &UpsertPointsRequest{
+		Points:       make([]PointStruct, len(points)),
+		WriteOptions: options,
+	}
+	for i, point := range points {
+		vector := NewVectorsUnnamed(vectors[i])
+		if vectorName != "" {
+			vector = NewVectorsNamed(map[string]*Vector{vectorName: vectors[i]})
+		}
+
+		request.Points[i] = PointStruct{
+			ID:      point.ID,
+			Vector:  vector,
+			Payload: point.Payload,
+		}
+	}
+
+	return c.UpsertPoints(ctx, collectionName, request)
+}
+
+// HashingEmbedder is a deterministic embedder that hashes words into a dense vector
+// of fixed dimension; it is meant for tests and carries no semantic meaning
+type HashingEmbedder struct {
+	Dim int
+}
+
+// NewHashingEmbedder creates a hashing embedder producing vectors of the given dimension
+func NewHashingEmbedder(dim int) *HashingEmbedder {
+	return &HashingEmbedder{Dim: dim}
+}
+
+// EmbedDocuments embeds each text independently
+func (e *HashingEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([]*Vector, error) {
+	if e.Dim <= 0 {
+		return nil, fmt.Errorf("hashing embedder dimension must be positive")
+	}
+
+	vectors := make([]*Vector, len(texts))
+	for i, text := range texts {
+		vectors[i] = NewVectorDense(e.embed(text))
+	}
+	return vectors, nil
+}
+
+// EmbedQueries embeds queries the same way as documents
+func (e *HashingEmbedder) EmbedQueries(ctx context.Context, texts []string) ([]*Vector, error) {
+	return e.EmbedDocuments(ctx, texts)
+}
+
+// embed hashes every lowercased word of the text into a signed bucket and normalizes the result
+func (e *HashingEmbedder) embed(text string) []float32 {
+	vector := make([]float32, e.Dim)
+
+	var tokenizer Tokenizer
+	for _, word := range tokenizer.Tokenize(text) {
+		h := fnv.New64a()
+		h.Write([]byte(word))
+		sum := h.Sum64()
+
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vector[sum%uint64(e.Dim)] += sign
+	}
+
+	var norm float64
+	for _, value := range vector {
+		norm += float64(value) * float64(value)
+	}
+	if norm > 0 {
+		scale := float32(1 / math.Sqrt(norm))
+		for i := range vector {
+			vector[i] *= scale
+		}
+	}
+
+	return vector
+}
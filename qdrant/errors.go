@@ -0,0 +1,80 @@
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// APIError represents an error response from the server
+// Message holds the error description from the response status when there is one,
+// otherwise the raw response body
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       string
+}
+
+// Error returns the status code and the response body
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError reads the body of an unsuccessful response into an APIError
+func newAPIError(resp *http.Response) *APIError {
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(bodyBytes),
+		Body:       string(bodyBytes),
+	}
+
+	var body struct {
+		Status struct {
+			Error string `json:"error"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(bodyBytes, &body); err == nil && body.Status.Error != "" {
+		apiErr.Message = body.Status.Error
+	}
+	return apiErr
+}
+
+// PeerHasShardsError is returned when a peer cannot be taken out of the cluster because
+// it still holds shard replicas; move or drop them first, or force the removal
+type PeerHasShardsError struct {
+	PeerID uint64
+	Err    *APIError
+}
+
+// Error describes the peer and the server message
+func (e *PeerHasShardsError) Error() string {
+	return fmt.Sprintf("peer %d still holds shards: %s", e.PeerID, e.Err.Message)
+}
+
+// Unwrap returns the underlying server error
+func (e *PeerHasShardsError) Unwrap() error {
+	return e.Err
+}
+
+// peerShardsPattern matches the server message for a peer that still holds shards
+var peerShardsPattern = regexp.MustCompile(`peer (\d+) as there are shards on it`)
+
+// clusterError converts a server error into a PeerHasShardsError when the server refused
+// to remove a peer because of its shards, and returns it unchanged otherwise
+// The peer ID is taken from the message when the caller does not know it
+func clusterError(apiErr *APIError, peerID uint64) error {
+	if apiErr.StatusCode != http.StatusBadRequest || !strings.Contains(apiErr.Message, "shards on it") {
+		return apiErr
+	}
+
+	if match := peerShardsPattern.FindStringSubmatch(apiErr.Message); match != nil && peerID == 0 {
+		peerID, _ = strconv.ParseUint(match[1], 10, 64)
+	}
+	return &PeerHasShardsError{PeerID: peerID, Err: apiErr}
+}
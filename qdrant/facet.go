@@ -0,0 +1,169 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// FacetRequest represents the request body for counting distinct payload values
+type FacetRequest struct {
+	Key    string  `json:"key"`
+	Filter *Filter `json:"filter,omitempty"`
+	Limit  uint64  `json:"limit,omitempty"`
+	Exact  *bool   `json:"exact,omitempty"`
+}
+
+// validate checks the request before it is sent
+func (r *FacetRequest) validate() error {
+	if err := validateKeyPath(r.Key); err != nil {
+		return fmt.Errorf("facet key: %w", err)
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	return nil
+}
+
+// FacetValue represents a distinct payload value, which is a keyword, an integer or a bool
+// Exactly one of the fields is set
+type FacetValue struct {
+	Keyword *string
+	Integer *int64
+	Bool    *bool
+}
+
+// Value returns the facet value as a plain Go value
+func (v FacetValue) Value() interface{} {
+	switch {
+	case v.Keyword != nil:
+		return *v.Keyword
+	case v.Integer != nil:
+		return *v.Integer
+	case v.Bool != nil:
+		return *v.Bool
+	default:
+		return nil
+	}
+}
+
+// String returns the textual form of the facet value
+func (v FacetValue) String() string {
+	switch {
+	case v.Keyword != nil:
+		return *v.Keyword
+	case v.Integer != nil:
+		return strconv.FormatInt(*v.Integer, 10)
+	case v.Bool != nil:
+		return strconv.FormatBool(*v.Bool)
+	default:
+		return ""
+	}
+}
+
+// Condition creates a filter condition matching points that have this value under key
+func (v FacetValue) Condition(key string) Condition {
+	return NewMatch(key, v.Value())
+}
+
+// MarshalJSON encodes the facet value as a JSON string, number or bool
+func (v FacetValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value())
+}
+
+// UnmarshalJSON decodes a facet value from a JSON string, number or bool
+func (v *FacetValue) UnmarshalJSON(data []byte) error {
+	*v = FacetValue{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("empty facet value")
+	}
+
+	switch data[0] {
+	case 'n':
+		return fmt.Errorf("facet value must be a string, integer or bool, got null")
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v.Keyword = &s
+	case 't', 'f':
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		v.Bool = &b
+	default:
+		var i int64
+		if err := json.Unmarshal(data, &i); err != nil {
+			return fmt.Errorf("facet value must be a string, integer or bool: %w", err)
+		}
+		v.Integer = &i
+	}
+	return nil
+}
+
+// FacetHit represents a distinct value and the number of points that have it
+type FacetHit struct {
+	Value FacetValue `json:"value"`
+	Count uint64     `json:"count"`
+}
+
+// FacetResult contains the facet hits, ordered by count
+type FacetResult struct {
+	Hits []FacetHit `json:"hits"`
+}
+
+// FacetResponse represents the response from a facet request
+type FacetResponse struct {
+	Usage  *Usage      `json:"usage"`
+	Time   float64     `json:"time"`
+	Status string      `json:"status"`
+	Result FacetResult `json:"result"`
+}
+
+// Facet counts the points for each distinct value of a payload key
+// The key must have a keyword, integer or bool payload index
+func (c *Client) Facet(ctx context.Context, collectionName string, request *FacetRequest) (*FacetResponse, error) {
+	path := fmt.Sprintf("/collections/%s/facet", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response FacetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
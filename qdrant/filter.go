@@ -0,0 +1,460 @@
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Filter represents a set of conditions used to narrow down points
+type Filter struct {
+	Should    []Condition `json:"should,omitempty"`
+	MinShould *MinShould  `json:"min_should,omitempty"`
+	Must      []Condition `json:"must,omitempty"`
+	MustNot   []Condition `json:"must_not,omitempty"`
+}
+
+// MinShould requires at least MinCount of the given conditions to match
+type MinShould struct {
+	Conditions []Condition `json:"conditions"`
+	MinCount   uint64      `json:"min_count"`
+}
+
+// Condition represents a single filter condition
+// Only one kind of condition should be set at a time
+type Condition struct {
+	Key            string          `json:"key,omitempty"`
+	Match          *Match          `json:"match,omitempty"`
+	Range          *Range          `json:"range,omitempty"`
+	GeoRadius      *GeoRadius      `json:"geo_radius,omitempty"`
+	GeoBoundingBox *GeoBoundingBox `json:"geo_bounding_box,omitempty"`
+	GeoPolygon     *GeoPolygon     `json:"geo_polygon,omitempty"`
+	ValuesCount    *ValuesCount    `json:"values_count,omitempty"`
+	IsEmpty        *PayloadField   `json:"is_empty,omitempty"`
+	IsNull         *PayloadField   `json:"is_null,omitempty"`
+	HasID          []PointID       `json:"has_id,omitempty"`
+	HasVector      string          `json:"has_vector,omitempty"`
+	Nested         *Nested         `json:"nested,omitempty"`
+	Filter         *Filter         `json:"-"`
+}
+
+// MarshalJSON encodes the condition, inlining nested filters
+func (c Condition) MarshalJSON() ([]byte, error) {
+	if c.Filter != nil {
+		return json.Marshal(c.Filter)
+	}
+
+	type condition Condition
+	return json.Marshal(condition(c))
+}
+
+// Match represents a match clause of a field condition
+// Text, Phrase and TextAny require a full-text index on the key
+type Match struct {
+	Value   interface{}   `json:"value,omitempty"`
+	Any     []interface{} `json:"any,omitempty"`
+	Except  []interface{} `json:"except,omitempty"`
+	Text    string        `json:"text,omitempty"`
+	Phrase  string        `json:"phrase,omitempty"`
+	TextAny string        `json:"text_any,omitempty"`
+}
+
+// Range represents a numeric range clause of a field condition
+type Range struct {
+	Lt  *float64 `json:"lt,omitempty"`
+	Gt  *float64 `json:"gt,omitempty"`
+	Gte *float64 `json:"gte,omitempty"`
+	Lte *float64 `json:"lte,omitempty"`
+}
+
+// ValuesCount represents a clause on the number of values stored under a key
+type ValuesCount struct {
+	Lt  *uint64 `json:"lt,omitempty"`
+	Gt  *uint64 `json:"gt,omitempty"`
+	Gte *uint64 `json:"gte,omitempty"`
+	Lte *uint64 `json:"lte,omitempty"`
+}
+
+// GeoPoint represents a geographical location
+type GeoPoint struct {
+	Lon float64 `json:"lon"`
+	Lat float64 `json:"lat"`
+}
+
+// validate checks that the coordinates are within their valid ranges
+func (p GeoPoint) validate() error {
+	if p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("longitude %v is out of range", p.Lon)
+	}
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude %v is out of range", p.Lat)
+	}
+	return nil
+}
+
+// GeoRadius represents a circle around a center, with the radius in meters
+type GeoRadius struct {
+	Center GeoPoint `json:"center"`
+	Radius float64  `json:"radius"`
+}
+
+// GeoBoundingBox represents a rectangle given by its top left and bottom right corners
+type GeoBoundingBox struct {
+	TopLeft     GeoPoint `json:"top_left"`
+	BottomRight GeoPoint `json:"bottom_right"`
+}
+
+// GeoLineString represents a closed ring of points, where the first and last points are equal
+type GeoLineString struct {
+	Points []GeoPoint `json:"points"`
+}
+
+// validate checks that the ring is closed and has enough points to enclose an area
+func (l GeoLineString) validate() error {
+	if len(l.Points) < 4 {
+		return fmt.Errorf("ring must have at least 4 points, got %d", len(l.Points))
+	}
+	if l.Points[0] != l.Points[len(l.Points)-1] {
+		return fmt.Errorf("ring must be closed")
+	}
+	for i, point := range l.Points {
+		if err := point.validate(); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GeoPolygon represents a polygon with an exterior ring and optional interior holes
+type GeoPolygon struct {
+	Exterior  GeoLineString   `json:"exterior"`
+	Interiors []GeoLineString `json:"interiors,omitempty"`
+}
+
+// validate checks every ring of the polygon
+func (p *GeoPolygon) validate() error {
+	if err := p.Exterior.validate(); err != nil {
+		return fmt.Errorf("exterior: %w", err)
+	}
+	for i, interior := range p.Interiors {
+		if err := interior.validate(); err != nil {
+			return fmt.Errorf("interior %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// PayloadField references a payload key
+type PayloadField struct {
+	Key string `json:"key"`
+}
+
+// Nested represents a filter applied to each element of an array of objects
+// A point matches when a single element satisfies the whole filter; keys inside
+// the filter are relative to the array elements
+type Nested struct {
+	Key    string `json:"key"`
+	Filter Filter `json:"filter"`
+}
+
+// NewMatch creates a condition matching a keyword, integer or bool value
+func NewMatch(key string, value interface{}) Condition {
+	return Condition{Key: key, Match: &Match{Value: value}}
+}
+
+// NewMatchAny creates a condition matching any of the given values
+func NewMatchAny(key string, values ...interface{}) Condition {
+	return Condition{Key: key, Match: &Match{Any: values}}
+}
+
+// NewMatchExcept creates a condition matching none of the given values
+func NewMatchExcept(key string, values ...interface{}) Condition {
+	return Condition{Key: key, Match: &Match{Except: values}}
+}
+
+// NewMatchText creates a condition matching texts that contain all tokens of the query
+func NewMatchText(key string, text string) Condition {
+	return Condition{Key: key, Match: &Match{Text: text}}
+}
+
+// NewMatchPhrase creates a condition matching texts that contain the tokens of the
+// query next to each other and in order
+// The full-text index must be created with phrase matching enabled
+func NewMatchPhrase(key string, phrase string) Condition {
+	return Condition{Key: key, Match: &Match{Phrase: phrase}}
+}
+
+// NewMatchTextAny creates a condition matching texts that contain any token of the query
+func NewMatchTextAny(key string, text string) Condition {
+	return Condition{Key: key, Match: &Match{TextAny: text}}
+}
+
+// NewRange creates a condition on a numeric range
+func NewRange(key string, r Range) Condition {
+	return Condition{Key: key, Range: &r}
+}
+
+// NewGeoRadius creates a condition matching geo points within radius meters of center
+func NewGeoRadius(key string, center GeoPoint, radius float64) Condition {
+	return Condition{Key: key, GeoRadius: &GeoRadius{Center: center, Radius: radius}}
+}
+
+// NewGeoBoundingBox creates a condition matching geo points inside a rectangle
+func NewGeoBoundingBox(key string, topLeft GeoPoint, bottomRight GeoPoint) Condition {
+	return Condition{Key: key, GeoBoundingBox: &GeoBoundingBox{TopLeft: topLeft, BottomRight: bottomRight}}
+}
+
+// NewGeoPolygon creates a condition matching geo points inside the exterior ring
+// and outside every interior ring
+func NewGeoPolygon(key string, exterior []GeoPoint, interiors ...[]GeoPoint) Condition {
+	polygon := &GeoPolygon{Exterior: GeoLineString{Points: exterior}}
+	for _, interior := range interiors {
+		polygon.Interiors = append(polygon.Interiors, GeoLineString{Points: interior})
+	}
+	return Condition{Key: key, GeoPolygon: polygon}
+}
+
+// NewValuesCount creates a condition on the number of values stored under a key
+func NewValuesCount(key string, count ValuesCount) Condition {
+	return Condition{Key: key, ValuesCount: &count}
+}
+
+// NewIsEmpty creates a condition matching points where the key is missing or empty
+func NewIsEmpty(key string) Condition {
+	return Condition{IsEmpty: &PayloadField{Key: key}}
+}
+
+// NewIsNull creates a condition matching points where the key is null
+func NewIsNull(key string) Condition {
+	return Condition{IsNull: &PayloadField{Key: key}}
+}
+
+// NewHasID creates a condition matching the given point IDs
+func NewHasID(ids ...PointID) Condition {
+	return Condition{HasID: ids}
+}
+
+// NewHasVector creates a condition matching points that have the named vector
+func NewHasVector(name string) Condition {
+	return Condition{HasVector: name}
+}
+
+// NewNested creates a condition matching points where one element of the array
+// under key satisfies the whole filter
+// A nil filter is rejected when the condition is validated
+func NewNested(key string, filter *Filter) Condition {
+	nested := &Nested{Key: key}
+	if filter != nil {
+		nested.Filter = *filter
+	}
+	return Condition{Nested: nested}
+}
+
+// NewFilterAsCondition wraps a filter so it can be used as a condition of another filter
+func NewFilterAsCondition(filter *Filter) Condition {
+	return Condition{Filter: filter}
+}
+
+// validate checks every condition of the filter before it is sent
+func (f *Filter) validate() error {
+	if f == nil {
+		return nil
+	}
+
+	if err := validateConditions("should", f.Should); err != nil {
+		return err
+	}
+	if f.MinShould != nil {
+		if err := validateConditions("min_should", f.MinShould.Conditions); err != nil {
+			return err
+		}
+	}
+	if err := validateConditions("must", f.Must); err != nil {
+		return err
+	}
+	return validateConditions("must_not", f.MustNot)
+}
+
+// validateConditions checks a list of conditions of a filter clause
+func validateConditions(clause string, conditions []Condition) error {
+	for i := range conditions {
+		if err := conditions[i].validate(); err != nil {
+			return fmt.Errorf("%s condition %d: %w", clause, i, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a single condition
+func (c *Condition) validate() error {
+	if c.Filter != nil {
+		return c.Filter.validate()
+	}
+
+	if c.Key != "" {
+		if err := validateKeyPath(c.Key); err != nil {
+			return err
+		}
+	} else if c.Match != nil || c.Range != nil || c.GeoRadius != nil || c.GeoBoundingBox != nil || c.GeoPolygon != nil || c.ValuesCount != nil {
+		return fmt.Errorf("field condition requires a key")
+	}
+	if c.Match != nil {
+		if err := c.Match.validate(); err != nil {
+			return fmt.Errorf("match: %w", err)
+		}
+	}
+	if c.IsEmpty != nil {
+		if err := validateKeyPath(c.IsEmpty.Key); err != nil {
+			return fmt.Errorf("is_empty: %w", err)
+		}
+	}
+	if c.IsNull != nil {
+		if err := validateKeyPath(c.IsNull.Key); err != nil {
+			return fmt.Errorf("is_null: %w", err)
+		}
+	}
+	if c.Nested != nil {
+		if err := c.Nested.validate(); err != nil {
+			return fmt.Errorf("nested: %w", err)
+		}
+	}
+
+	if c.GeoRadius != nil {
+		if err := c.GeoRadius.Center.validate(); err != nil {
+			return fmt.Errorf("geo_radius center: %w", err)
+		}
+		if c.GeoRadius.Radius <= 0 {
+			return fmt.Errorf("geo_radius radius must be positive")
+		}
+	}
+	if c.GeoBoundingBox != nil {
+		if err := c.GeoBoundingBox.TopLeft.validate(); err != nil {
+			return fmt.Errorf("geo_bounding_box top_left: %w", err)
+		}
+		if err := c.GeoBoundingBox.BottomRight.validate(); err != nil {
+			return fmt.Errorf("geo_bounding_box bottom_right: %w", err)
+		}
+	}
+	if c.GeoPolygon != nil {
+		if err := c.GeoPolygon.validate(); err != nil {
+			return fmt.Errorf("geo_polygon: %w", err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the match has something to compare against
+// Empty text queries are indistinguishable from unset ones, so they are rejected too
+func (m *Match) validate() error {
+	for _, text := range []string{m.Text, m.Phrase, m.TextAny} {
+		if text != "" && strings.TrimSpace(text) == "" {
+			return fmt.Errorf("text query must not be blank")
+		}
+	}
+	if m.Value == nil && len(m.Any) == 0 && len(m.Except) == 0 && m.Text == "" && m.Phrase == "" && m.TextAny == "" {
+		return fmt.Errorf("match requires a value, a list of values or a non-empty text query")
+	}
+	return nil
+}
+
+// validate checks the array key and the filter applied to its elements
+func (n *Nested) validate() error {
+	if err := validateKeyPath(n.Key); err != nil {
+		return err
+	}
+	if len(n.Filter.Should) == 0 && len(n.Filter.Must) == 0 && len(n.Filter.MustNot) == 0 && n.Filter.MinShould == nil {
+		return fmt.Errorf("nested filter must have at least one condition")
+	}
+	if hasIDCondition(&n.Filter) {
+		return fmt.Errorf("has_id is not supported inside nested filters, use it in the outer filter")
+	}
+	return n.Filter.validate()
+}
+
+// hasIDCondition reports whether any clause of the filter, or of the filters and nested
+// conditions inside it, contains a has_id condition
+func hasIDCondition(f *Filter) bool {
+	clauses := [][]Condition{f.Should, f.Must, f.MustNot}
+	if f.MinShould != nil {
+		clauses = append(clauses, f.MinShould.Conditions)
+	}
+	for _, conditions := range clauses {
+		for i := range conditions {
+			condition := &conditions[i]
+			switch {
+			case len(condition.HasID) > 0:
+				return true
+			case condition.Filter != nil && hasIDCondition(condition.Filter):
+				return true
+			case condition.Nested != nil && hasIDCondition(&condition.Nested.Filter):
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateKeyPath checks the syntax of a payload key path such as country.cities[].name
+// A path is made of dot separated keys, each optionally double quoted so it can contain
+// dots, and each followed by any number of [] array projections or [N] array indexes
+func validateKeyPath(key string) error {
+	if key == "" {
+		return fmt.Errorf("key must not be empty")
+	}
+
+	i := 0
+	for {
+		// Each segment starts with a bare or quoted key
+		start := i
+		if key[i] == '"' {
+			i++
+			for i < len(key) && key[i] != '"' {
+				i++
+			}
+			if i == len(key) {
+				return fmt.Errorf("key %q has an unterminated quote", key)
+			}
+			if i == start+1 {
+				return fmt.Errorf("key %q has an empty quoted segment", key)
+			}
+			i++
+		} else {
+			for i < len(key) && key[i] != '.' && key[i] != '[' {
+				if key[i] == ']' || key[i] == '"' {
+					return fmt.Errorf("key %q has an unexpected %q at position %d", key, key[i], i)
+				}
+				i++
+			}
+			if i == start {
+				return fmt.Errorf("key %q has an empty segment at position %d", key, i)
+			}
+		}
+
+		// Followed by array projections or indexes
+		for i < len(key) && key[i] == '[' {
+			i++
+			digits := i
+			for i < len(key) && key[i] >= '0' && key[i] <= '9' {
+				i++
+			}
+			if i == len(key) || key[i] != ']' {
+				if i > digits || i == len(key) {
+					return fmt.Errorf("key %q has an unterminated bracket", key)
+				}
+				return fmt.Errorf("key %q has an invalid array index at position %d", key, i)
+			}
+			i++
+		}
+
+		if i == len(key) {
+			return nil
+		}
+		if key[i] != '.' {
+			return fmt.Errorf("key %q has an unexpected %q at position %d", key, key[i], i)
+		}
+		i++
+		if i == len(key) {
+			return fmt.Errorf("key %q must not end with a dot", key)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Expression represents a node of a score boosting formula
+// Expressions are built with the NewExpr functions, and errors in the
+// arguments are reported when the query containing them is sent
+type Expression struct {
+	value interface{}
+	err   error
+}
+
+// MarshalJSON encodes the expression in the form expected by the API
+func (e Expression) MarshalJSON() ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.value == nil {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	return json.Marshal(e.value)
+}
+
+// validate returns the first error found while building the expression
+func (e *Expression) validate() error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.value == nil {
+		return fmt.Errorf("expression is empty")
+	}
+	return nil
+}
+
+// newExpr creates an operator expression, propagating errors of its arguments
+func newExpr(op string, value interface{}, args ...Expression) Expression {
+	for _, arg := range args {
+		if err := arg.validate(); err != nil {
+			return Expression{err: fmt.Errorf("%s: %w", op, err)}
+		}
+	}
+	return Expression{value: map[string]interface{}{op: value}}
+}
+
+// NewExprConstant creates a constant expression
+func NewExprConstant(value float64) Expression {
+	return Expression{value: value}
+}
+
+// NewExprVariable creates an expression that reads a numeric payload value
+func NewExprVariable(key string) Expression {
+	if key == "" {
+		return Expression{err: fmt.Errorf("variable key must not be empty")}
+	}
+	return Expression{value: key}
+}
+
+// NewExprScore creates an expression that reads the score of the first prefetch
+func NewExprScore() Expression {
+	return Expression{value: "$score"}
+}
+
+// NewExprPrefetchScore creates an expression that reads the score of the prefetch at the given index
+func NewExprPrefetchScore(index int) Expression {
+	if index < 0 {
+		return Expression{err: fmt.Errorf("prefetch index must not be negative")}
+	}
+	return Expression{value: fmt.Sprintf("$score[%d]", index)}
+}
+
+// NewExprCondition creates an expression that is 1 when the condition matches and 0 otherwise
+func NewExprCondition(condition Condition) Expression {
+	return Expression{value: condition}
+}
+
+// NewExprSum creates an expression that adds its arguments
+func NewExprSum(args ...Expression) Expression {
+	if len(args) == 0 {
+		return Expression{err: fmt.Errorf("sum requires at least one argument")}
+	}
+	return newExpr("sum", args, args...)
+}
+
+// NewExprMult creates an expression that multiplies its arguments
+func NewExprMult(args ...Expression) Expression {
+	if len(args) == 0 {
+		return Expression{err: fmt.Errorf("mult requires at least one argument")}
+	}
+	return newExpr("mult", args, args...)
+}
+
+// NewExprDiv creates an expression that divides left by right
+// When right is zero the expression evaluates to byZeroDefault if it is set
+func NewExprDiv(left Expression, right Expression, byZeroDefault *float64) Expression {
+	return newExpr("div", struct {
+		Left          Expression `json:"left"`
+		Right         Expression `json:"right"`
+		ByZeroDefault *float64   `json:"by_zero_default,omitempty"`
+	}{
+		Left:          left,
+		Right:         right,
+		ByZeroDefault: byZeroDefault,
+	}, left, right)
+}
+
+// NewExprNeg creates an expression that negates its argument
+func NewExprNeg(arg Expression) Expression {
+	return newExpr("neg", arg, arg)
+}
+
+// NewExprAbs creates an expression that returns the absolute value of its argument
+func NewExprAbs(arg Expression) Expression {
+	return newExpr("abs", arg, arg)
+}
+
+// NewExprSqrt creates an expression that returns the square root of its argument
+func NewExprSqrt(arg Expression) Expression {
+	return newExpr("sqrt", arg, arg)
+}
+
+// NewExprPow creates an expression that raises base to the power of exponent
+func NewExprPow(base Expression, exponent Expression) Expression {
+	return newExpr("pow", struct {
+		Base     Expression `json:"base"`
+		Exponent Expression `json:"exponent"`
+	}{
+		Base:     base,
+		Exponent: exponent,
+	}, base, exponent)
+}
+
+// NewExprExp creates an expression that returns e raised to the power of its argument
+func NewExprExp(arg Expression) Expression {
+	return newExpr("exp", arg, arg)
+}
+
+// NewExprLog10 creates an expression that returns the base 10 logarithm of its argument
+func NewExprLog10(arg Expression) Expression {
+	return newExpr("log10", arg, arg)
+}
+
+// NewExprLn creates an expression that returns the natural logarithm of its argument
+func NewExprLn(arg Expression) Expression {
+	return newExpr("ln", arg, arg)
+}
+
+// NewExprGeoDistance creates an expression that returns the distance in meters
+// between origin and the geo point stored under key
+func NewExprGeoDistance(origin GeoPoint, key string) Expression {
+	if key == "" {
+		return Expression{err: fmt.Errorf("geo_distance key must not be empty")}
+	}
+	return newExpr("geo_distance", struct {
+		Origin GeoPoint `json:"origin"`
+		To     string   `json:"to"`
+	}{
+		Origin: origin,
+		To:     key,
+	})
+}
+
+// NewExprDatetime creates a constant datetime expression
+func NewExprDatetime(t time.Time) Expression {
+	return newExpr("datetime", t.UTC().Format(time.RFC3339Nano))
+}
+
+// NewExprDatetimeKey creates an expression that reads a datetime payload value
+func NewExprDatetimeKey(key string) Expression {
+	if key == "" {
+		return Expression{err: fmt.Errorf("datetime_key must not be empty")}
+	}
+	return newExpr("datetime_key", key)
+}
+
+// DecayParams represents the parameters of a decay function
+// Target defaults to 0, Scale to 1 and Midpoint to 0.5; Scale is expressed
+// in the same unit as X and Midpoint is the output value at distance Scale
+type DecayParams struct {
+	X        Expression
+	Target   *Expression
+	Scale    *float64
+	Midpoint *float64
+}
+
+// newDecayExpr validates decay parameters and creates the decay expression
+func newDecayExpr(op string, params DecayParams) Expression {
+	if params.Scale != nil && *params.Scale <= 0 {
+		return Expression{err: fmt.Errorf("%s: scale must be positive", op)}
+	}
+	if params.Midpoint != nil && (*params.Midpoint <= 0 || *params.Midpoint >= 1) {
+		return Expression{err: fmt.Errorf("%s: midpoint must be between 0 and 1 exclusive", op)}
+	}
+
+	args := []Expression{params.X}
+	if params.Target != nil {
+		args = append(args, *params.Target)
+	}
+
+	return newExpr(op, struct {
+		X        Expression  `json:"x"`
+		Target   *Expression `json:"target,omitempty"`
+		Scale    *float64    `json:"scale,omitempty"`
+		Midpoint *float64    `json:"midpoint,omitempty"`
+	}{
+		X:        params.X,
+		Target:   params.Target,
+		Scale:    params.Scale,
+		Midpoint: params.Midpoint,
+	}, args...)
+}
+
+// NewExprExpDecay creates an exponential decay expression
+func NewExprExpDecay(params DecayParams) Expression {
+	return newDecayExpr("exp_decay", params)
+}
+
+// NewExprGaussDecay creates a gaussian decay expression
+func NewExprGaussDecay(params DecayParams) Expression {
+	return newDecayExpr("gauss_decay", params)
+}
+
+// NewExprLinDecay creates a linear decay expression
+func NewExprLinDecay(params DecayParams) Expression {
+	return newDecayExpr("lin_decay", params)
+}
@@ -0,0 +1,130 @@
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// geoJSONObject represents the fields of a GeoJSON object used for conversion
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Geometries  []json.RawMessage `json:"geometries"`
+	Features    []json.RawMessage `json:"features"`
+}
+
+// GeoJSONToFilter converts a GeoJSON document into a filter matching points whose
+// geo payload under key lies inside its polygons
+// Polygon and MultiPolygon geometries are supported, on their own or inside a
+// Feature, FeatureCollection or GeometryCollection; when the document holds
+// several polygons a point matches if it lies inside any of them
+func GeoJSONToFilter(key string, data []byte) (*Filter, error) {
+	conditions, err := GeoJSONToConditions(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conditions) == 1 {
+		return &Filter{Must: conditions}, nil
+	}
+	return &Filter{Should: conditions}, nil
+}
+
+// GeoJSONToConditions converts a GeoJSON document into one polygon condition per polygon
+func GeoJSONToConditions(key string, data []byte) ([]Condition, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key must not be empty")
+	}
+
+	var conditions []Condition
+	if err := appendGeoJSONConditions(&conditions, key, data); err != nil {
+		return nil, err
+	}
+
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("geojson document contains no polygons")
+	}
+	return conditions, nil
+}
+
+// appendGeoJSONConditions decodes a GeoJSON object and appends its polygons as conditions
+func appendGeoJSONConditions(conditions *[]Condition, key string, data []byte) error {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("decoding geojson: %w", err)
+	}
+
+	switch object.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &rings); err != nil {
+			return fmt.Errorf("decoding polygon coordinates: %w", err)
+		}
+
+		condition, err := geoJSONPolygonCondition(key, rings)
+		if err != nil {
+			return err
+		}
+		*conditions = append(*conditions, condition)
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("decoding multipolygon coordinates: %w", err)
+		}
+
+		for i, rings := range polygons {
+			condition, err := geoJSONPolygonCondition(key, rings)
+			if err != nil {
+				return fmt.Errorf("polygon %d: %w", i, err)
+			}
+			*conditions = append(*conditions, condition)
+		}
+	case "Feature":
+		if len(object.Geometry) == 0 || string(object.Geometry) == "null" {
+			return nil
+		}
+		return appendGeoJSONConditions(conditions, key, object.Geometry)
+	case "FeatureCollection":
+		for i, feature := range object.Features {
+			if err := appendGeoJSONConditions(conditions, key, feature); err != nil {
+				return fmt.Errorf("feature %d: %w", i, err)
+			}
+		}
+	case "GeometryCollection":
+		for i, geometry := range object.Geometries {
+			if err := appendGeoJSONConditions(conditions, key, geometry); err != nil {
+				return fmt.Errorf("geometry %d: %w", i, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported geojson type %q", object.Type)
+	}
+	return nil
+}
+
+// geoJSONPolygonCondition converts the rings of a GeoJSON polygon into a polygon condition
+// The first ring is the exterior and the others are holes
+func geoJSONPolygonCondition(key string, rings [][][]float64) (Condition, error) {
+	if len(rings) == 0 {
+		return Condition{}, fmt.Errorf("polygon has no rings")
+	}
+
+	points := make([][]GeoPoint, len(rings))
+	for i, ring := range rings {
+		points[i] = make([]GeoPoint, len(ring))
+		for j, position := range ring {
+			// GeoJSON positions are longitude first, optionally followed by altitude
+			if len(position) < 2 {
+				return Condition{}, fmt.Errorf("ring %d position %d must have longitude and latitude", i, j)
+			}
+			points[i][j] = GeoPoint{Lon: position[0], Lat: position[1]}
+		}
+	}
+
+	condition := NewGeoPolygon(key, points[0], points[1:]...)
+	if err := condition.GeoPolygon.validate(); err != nil {
+		return Condition{}, err
+	}
+	return condition, nil
+}
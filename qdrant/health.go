@@ -0,0 +1,379 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultHealthPollInterval is how often the monitor checks the cluster when unset
+const defaultHealthPollInterval = 10 * time.Second
+
+// defaultRepairInterval is the minimum time between two repairs when unset
+const defaultRepairInterval = time.Minute
+
+// HealthEventType describes what the health monitor found or did
+type HealthEventType string
+
+const (
+	HealthEventReplicaUnhealthy HealthEventType = "replica_unhealthy"
+	HealthEventUnderReplicated  HealthEventType = "under_replicated"
+	HealthEventReplicaDropped   HealthEventType = "replica_dropped"
+	HealthEventRepairStarted    HealthEventType = "repair_started"
+	HealthEventRepairFailed     HealthEventType = "repair_failed"
+	HealthEventCheckFailed      HealthEventType = "check_failed"
+)
+
+// HealthEvent is emitted by the health monitor
+// PeerID is the peer of an unhealthy or dropped replica or the target of a repair, and
+// FromPeerID the healthy peer a repair copies from. ShardKey is set for shards of a
+// collection with custom sharding
+type HealthEvent struct {
+	Type              HealthEventType
+	Collection        string
+	ShardID           uint64
+	ShardKey          *ShardKey
+	PeerID            uint64
+	FromPeerID        uint64
+	State             ReplicaState
+	ActiveReplicas    int
+	ReplicationFactor uint32
+	Err               error
+}
+
+// String describes the event
+func (e HealthEvent) String() string {
+	switch e.Type {
+	case HealthEventReplicaUnhealthy:
+		return fmt.Sprintf("shard %d of %q on peer %d is %s", e.ShardID, e.Collection, e.PeerID, e.State)
+	case HealthEventUnderReplicated:
+		return fmt.Sprintf("shard %d of %q has %d of %d active replicas", e.ShardID, e.Collection, e.ActiveReplicas, e.ReplicationFactor)
+	case HealthEventReplicaDropped:
+		return fmt.Sprintf("dropping dead replica of shard %d of %q on peer %d", e.ShardID, e.Collection, e.PeerID)
+	case HealthEventRepairStarted:
+		return fmt.Sprintf("replicating shard %d of %q from peer %d to peer %d", e.ShardID, e.Collection, e.FromPeerID, e.PeerID)
+	case HealthEventRepairFailed:
+		return fmt.Sprintf("repairing shard %d of %q failed: %v", e.ShardID, e.Collection, e.Err)
+	default:
+		return fmt.Sprintf("health check failed: %v", e.Err)
+	}
+}
+
+// HealthMonitorOptions controls how the health monitor polls and repairs
+type HealthMonitorOptions struct {
+	// Collections limits monitoring to the given collections, all collections when empty
+	Collections []string
+	// PollInterval is how often the cluster is checked, ten seconds when unset
+	PollInterval time.Duration
+	// Repair replicates under replicated shards to a healthy peer that lacks them
+	Repair bool
+	// RepairInterval is the minimum time between two repairs, one minute when unset
+	RepairInterval time.Duration
+	// ShardKeyReplicationFactors maps collections to the replication factor of their
+	// shard keys. The server does not report the factor a shard key was created with,
+	// so shards of keys missing here are held to the collection replication factor
+	ShardKeyReplicationFactors map[string]map[ShardKey]uint32
+	// OnEvent is called for every event, in the order they are found
+	OnEvent func(HealthEvent)
+}
+
+// HealthMonitor polls the cluster for unhealthy and under replicated shards
+type HealthMonitor struct {
+	client  *Client
+	options HealthMonitorOptions
+
+	repairMu   sync.Mutex
+	lastRepair time.Time
+}
+
+// NewHealthMonitor creates a health monitor using the client
+func NewHealthMonitor(client *Client, options HealthMonitorOptions) *HealthMonitor {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultHealthPollInterval
+	}
+	if options.RepairInterval <= 0 {
+		options.RepairInterval = defaultRepairInterval
+	}
+	return &HealthMonitor{client: client, options: options}
+}
+
+// Run checks the cluster every poll interval until the context is done and returns its error
+// A failed check is reported as a check_failed event and does not stop the monitor
+func (m *HealthMonitor) Run(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if _, err := m.Check(ctx); err != nil && ctx.Err() == nil {
+			m.emit(nil, HealthEvent{Type: HealthEventCheckFailed, Err: err})
+		}
+		timer.Reset(m.options.PollInterval)
+	}
+}
+
+// monitoredCollection holds what a check learned about a collection
+type monitoredCollection struct {
+	name              string
+	info              *CollectionClusterInfo
+	replicationFactor uint32
+}
+
+// Check inspects the cluster once and returns the events it found
+// Replicas that are Dead, Partial or in Recovery are reported unless a transfer to them
+// is running, and so are shards with fewer active replicas than the replication factor.
+// With Repair set, an under replicated shard without a running transfer is repaired at
+// most once per repair interval: a dead replica on a peer still in the cluster is
+// dropped and copied again from an active replica, and a shard with no dead replica
+// left to replace is replicated to the peer with the fewest replicas that lacks it
+func (m *HealthMonitor) Check(ctx context.Context) ([]HealthEvent, error) {
+	peers, err := m.client.clusterPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	load := make(map[uint64]int, len(peers))
+	for peerID := range peers {
+		load[peerID] = 0
+	}
+
+	names, err := m.client.collectionNames(ctx, m.options.Collections)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]monitoredCollection, 0, len(names))
+	for _, name := range names {
+		details, err := m.client.GetCollection(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("getting collection %q: %w", name, err)
+		}
+
+		response, err := m.client.GetCollectionClusterInfo(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("getting cluster info of %q: %w", name, err)
+		}
+
+		collection := monitoredCollection{name: name, info: &response.Result}
+		if details.Result != nil && details.Result.Config != nil {
+			collection.replicationFactor = details.Result.Config.Params.ReplicationFactor
+		}
+		collections = append(collections, collection)
+
+		for _, replica := range collection.info.Replicas() {
+			if _, ok := load[replica.PeerID]; ok {
+				load[replica.PeerID]++
+			}
+		}
+	}
+
+	var events []HealthEvent
+	for _, collection := range collections {
+		shards := make(map[uint64][]ShardReplica)
+		for _, replica := range collection.info.Replicas() {
+			shards[replica.ShardID] = append(shards[replica.ShardID], replica)
+		}
+
+		// Shards are grouped by shard key, whose replication factor may differ
+		shardIDs := make([]uint64, 0, len(shards))
+		for shardID := range shards {
+			shardIDs = append(shardIDs, shardID)
+		}
+		sort.Slice(shardIDs, func(i, j int) bool {
+			a, b := shardKeyString(shards[shardIDs[i]][0].ShardKey), shardKeyString(shards[shardIDs[j]][0].ShardKey)
+			if a != b {
+				return a < b
+			}
+			return shardIDs[i] < shardIDs[j]
+		})
+
+		for _, shardID := range shardIDs {
+			replicas := shards[shardID]
+			sort.Slice(replicas, func(i, j int) bool { return replicas[i].PeerID < replicas[j].PeerID })
+			shardKey := replicas[0].ShardKey
+			replicationFactor := m.replicationFactor(collection, shardKey)
+
+			transferring := false
+			active := 0
+			holders := make(map[uint64]bool)
+			for _, replica := range replicas {
+				holders[replica.PeerID] = true
+				if replica.State == ReplicaStateActive {
+					active++
+				}
+			}
+			for _, transfer := range collection.info.ShardTransfers {
+				if transfer.ShardID == shardID {
+					transferring = true
+				}
+			}
+
+			for _, replica := range replicas {
+				switch replica.State {
+				case ReplicaStateDead, ReplicaStatePartial, ReplicaStateRecovery:
+				default:
+					continue
+				}
+				if replica.State != ReplicaStateDead && receivesTransfer(collection.info.ShardTransfers, shardID, replica.PeerID) {
+					continue
+				}
+				events = m.emit(events, HealthEvent{
+					Type:       HealthEventReplicaUnhealthy,
+					Collection: collection.name,
+					ShardID:    shardID,
+					ShardKey:   shardKey,
+					PeerID:     replica.PeerID,
+					State:      replica.State,
+				})
+			}
+
+			if uint32(active) >= replicationFactor {
+				continue
+			}
+			events = m.emit(events, HealthEvent{
+				Type:              HealthEventUnderReplicated,
+				Collection:        collection.name,
+				ShardID:           shardID,
+				ShardKey:          shardKey,
+				ActiveReplicas:    active,
+				ReplicationFactor: replicationFactor,
+			})
+
+			if !m.options.Repair || transferring || !m.repairDue() {
+				continue
+			}
+			events = m.repair(ctx, events, collection.name, shardID, shardKey, replicas, holders, load)
+		}
+	}
+
+	return events, nil
+}
+
+// repair replicates a shard from its first active replica, replacing the first dead
+// replica on a peer that is still in the cluster or else adding a replica on the least
+// loaded peer without one
+func (m *HealthMonitor) repair(ctx context.Context, events []HealthEvent, collection string, shardID uint64, shardKey *ShardKey, replicas []ShardReplica, holders map[uint64]bool, load map[uint64]int) []HealthEvent {
+	event := HealthEvent{Collection: collection, ShardID: shardID, ShardKey: shardKey}
+
+	source, found := uint64(0), false
+	for _, replica := range replicas {
+		if replica.State == ReplicaStateActive {
+			source, found = replica.PeerID, true
+			break
+		}
+	}
+	if !found {
+		event.Type = HealthEventRepairFailed
+		event.Err = fmt.Errorf("no active replica to copy from")
+		return m.emit(events, event)
+	}
+
+	target, replace := uint64(0), false
+	for _, replica := range replicas {
+		if _, ok := load[replica.PeerID]; ok && replica.State == ReplicaStateDead {
+			target, replace = replica.PeerID, true
+			break
+		}
+	}
+	if !replace {
+		var ok bool
+		if target, ok = leastLoadedPeer(load, holders); !ok {
+			event.Type = HealthEventRepairFailed
+			event.FromPeerID = source
+			event.Err = fmt.Errorf("no peer without a replica of the shard")
+			return m.emit(events, event)
+		}
+	}
+
+	if !m.startRepair() {
+		return events
+	}
+	event.FromPeerID = source
+	event.PeerID = target
+
+	if replace {
+		// The server does not replicate onto a peer that still holds the shard
+		if _, err := m.client.DropReplica(ctx, collection, shardID, target); err != nil {
+			event.Type = HealthEventRepairFailed
+			event.Err = fmt.Errorf("dropping dead replica on peer %d: %w", target, err)
+			return m.emit(events, event)
+		}
+		events = m.emit(events, HealthEvent{Type: HealthEventReplicaDropped, Collection: collection, ShardID: shardID, ShardKey: shardKey, PeerID: target})
+	}
+
+	if _, err := m.client.ReplicateShard(ctx, collection, shardID, source, target); err != nil {
+		event.Type = HealthEventRepairFailed
+		event.Err = err
+		return m.emit(events, event)
+	}
+
+	if !replace {
+		load[target]++
+	}
+	event.Type = HealthEventRepairStarted
+	return m.emit(events, event)
+}
+
+// repairDue reports whether the repair interval has passed since the last repair
+func (m *HealthMonitor) repairDue() bool {
+	m.repairMu.Lock()
+	defer m.repairMu.Unlock()
+
+	return time.Since(m.lastRepair) >= m.options.RepairInterval
+}
+
+// startRepair records a repair and reports whether the repair interval allowed it, so
+// that concurrent checks never repair more than once per interval
+func (m *HealthMonitor) startRepair() bool {
+	m.repairMu.Lock()
+	defer m.repairMu.Unlock()
+
+	if time.Since(m.lastRepair) < m.options.RepairInterval {
+		return false
+	}
+	m.lastRepair = time.Now()
+	return true
+}
+
+// replicationFactor returns the replication factor a shard of the collection should have
+func (m *HealthMonitor) replicationFactor(collection monitoredCollection, shardKey *ShardKey) uint32 {
+	if shardKey != nil {
+		if factor, ok := m.options.ShardKeyReplicationFactors[collection.name][*shardKey]; ok {
+			return factor
+		}
+	}
+	return collection.replicationFactor
+}
+
+// shardKeyString returns the textual form of an optional shard key, empty when nil
+func shardKeyString(shardKey *ShardKey) string {
+	if shardKey == nil {
+		return ""
+	}
+	return shardKey.String()
+}
+
+// emit passes the event to the callback and appends it to the events
+func (m *HealthMonitor) emit(events []HealthEvent, event HealthEvent) []HealthEvent {
+	if m.options.OnEvent != nil {
+		m.options.OnEvent(event)
+	}
+	return append(events, event)
+}
+
+// receivesTransfer reports whether a transfer of the shard to the peer is running
+func receivesTransfer(transfers []ShardTransfer, shardID uint64, peerID uint64) bool {
+	for _, transfer := range transfers {
+		if transfer.ShardID == shardID && transfer.ToPeerID == peerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// rrfK is the rank constant of reciprocal rank fusion; like the server, a point at
+// zero-based position p of a list scores 1 / (rrfK + p)
+const rrfK = 2
+
+// FusionPath records where the results of a hybrid search were fused
+type FusionPath string
+
+const (
+	FusionPathServer FusionPath = "server"
+	FusionPathClient FusionPath = "client"
+)
+
+// HybridSearchRequest represents a combined dense and sparse search
+// Weights only apply when results are fused on the client; a nil weight counts as one
+// and a zero weight leaves the list out of the fusion
+type HybridSearchRequest struct {
+	Dense             []float32
+	DenseUsing        string
+	Sparse            *SparseVector
+	SparseUsing       string
+	Filter            *Filter
+	Params            *SearchParams
+	Limit             uint64
+	PrefetchLimit     uint64
+	Fusion            Fusion
+	DenseWeight       *float64
+	SparseWeight      *float64
+	WithPayload       *PayloadSelector
+	WithVector        *VectorSelector
+	ForceClientFusion bool
+}
+
+// HybridSearchResult contains the fused results of a hybrid search
+type HybridSearchResult struct {
+	Points     []ScoredPoint
+	Fusion     Fusion
+	FusionPath FusionPath
+}
+
+// validate checks the request before anything is sent
+func (r *HybridSearchRequest) validate() error {
+	if len(r.Dense) == 0 {
+		return fmt.Errorf("hybrid search requires a dense vector")
+	}
+	if r.Sparse == nil {
+		return fmt.Errorf("hybrid search requires a sparse vector")
+	}
+	if r.SparseUsing == "" {
+		return fmt.Errorf("hybrid search requires the name of the sparse vector field")
+	}
+	if r.Limit == 0 {
+		return fmt.Errorf("hybrid search requires a positive limit")
+	}
+	if (r.DenseWeight != nil && *r.DenseWeight < 0) || (r.SparseWeight != nil && *r.SparseWeight < 0) {
+		return fmt.Errorf("fusion weights must not be negative")
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	switch r.Fusion {
+	case "", FusionRRF, FusionDBSF:
+	default:
+		return fmt.Errorf("unsupported fusion %q", r.Fusion)
+	}
+	return r.Sparse.Validate()
+}
+
+// HybridSearch runs a dense and a sparse search and fuses their results into a single ranking
+// Servers that support fusion in the query API fuse the results themselves; on older
+// servers, or when the server version cannot be read, both searches are sent as a batch
+// and the results are fused on the client
+func (c *Client) HybridSearch(ctx context.Context, collectionName string, request *HybridSearchRequest) (*HybridSearchResult, error) {
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	fusion := request.Fusion
+	if fusion == "" {
+		fusion = FusionRRF
+	}
+
+	prefetchLimit := request.PrefetchLimit
+	if prefetchLimit == 0 {
+		prefetchLimit = request.Limit * 3
+	}
+
+	serverFusion := false
+	if !request.ForceClientFusion {
+		if version, err := c.serverVersion(ctx); err == nil {
+			serverFusion = supportsServerFusion(version, fusion)
+		}
+	}
+
+	if serverFusion {
+		return c.hybridSearchServer(ctx, collectionName, request, fusion, prefetchLimit)
+	}
+	return c.hybridSearchClient(ctx, collectionName, request, fusion, prefetchLimit)
+}
+
+// supportsServerFusion reports whether the server can fuse prefetches with the given algorithm
+func supportsServerFusion(version *VersionInfo, fusion Fusion) bool {
+	if fusion == FusionDBSF {
+		return version.AtLeast(1, 11)
+	}
+	return version.AtLeast(1, 10)
+}
+
+// hybridSearchServer sends both searches as prefetches of a single fusion query
+func (c *Client) hybridSearchServer(ctx context.Context, collectionName string, request *HybridSearchRequest, fusion Fusion, prefetchLimit uint64) (*HybridSearchResult, error) {
+	response, err := c.Query(ctx, collectionName, &QueryRequest{
+		Prefetch: []Prefetch{
+			{
+				Query:  NewQueryNearest(NewVectorInputDense(request.Dense)),
+				Using:  request.DenseUsing,
+				Filter: request.Filter,
+				Params: request.Params,
+				Limit:  prefetchLimit,
+			},
+			{
+				Query:  NewQueryNearest(NewVectorInputSparse(request.Sparse)),
+				Using:  request.SparseUsing,
+				Filter: request.Filter,
+				Params: request.Params,
+				Limit:  prefetchLimit,
+			},
+		},
+		Query:       NewQueryFusion(fusion),
+		Limit:       request.Limit,
+		WithPayload: request.WithPayload,
+		WithVector:  request.WithVector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HybridSearchResult{
+		Points:     response.Result.Points,
+		Fusion:     fusion,
+		FusionPath: FusionPathServer,
+	}, nil
+}
+
+// hybridSearchClient runs both searches in one batch and fuses the results locally
+func (c *Client) hybridSearchClient(ctx context.Context, collectionName string, request *HybridSearchRequest, fusion Fusion, prefetchLimit uint64) (*HybridSearchResult, error) {
+	response, err := c.SearchBatch(ctx, collectionName, &SearchBatchRequest{
+		Searches: []SearchRequest{
+			{
+				Vector:      NamedVector{Name: request.DenseUsing, Dense: request.Dense},
+				Filter:      request.Filter,
+				Params:      request.Params,
+				Limit:       prefetchLimit,
+				WithPayload: request.WithPayload,
+				WithVector:  request.WithVector,
+			},
+			{
+				Vector:      NamedVector{Name: request.SparseUsing, Sparse: request.Sparse},
+				Filter:      request.Filter,
+				Params:      request.Params,
+				Limit:       prefetchLimit,
+				WithPayload: request.WithPayload,
+				WithVector:  request.WithVector,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Result) != 2 {
+		return nil, fmt.Errorf("expected 2 result lists, got %d", len(response.Result))
+	}
+
+	weights := []float64{weightOrDefault(request.DenseWeight), weightOrDefault(request.SparseWeight)}
+
+	var points []ScoredPoint
+	if fusion == FusionDBSF {
+		points = FuseDBSF(response.Result, weights)
+	} else {
+		points = FuseRRF(response.Result, weights)
+	}
+
+	if uint64(len(points)) > request.Limit {
+		points = points[:request.Limit]
+	}
+
+	return &HybridSearchResult{
+		Points:     points,
+		Fusion:     fusion,
+		FusionPath: FusionPathClient,
+	}, nil
+}
+
+// weightOrDefault treats an unset weight as one
+func weightOrDefault(weight *float64) float64 {
+	if weight == nil {
+		return 1
+	}
+	return *weight
+}
+
+// FuseRRF combines ranked result lists with weighted reciprocal rank fusion
+// Each point scores the sum of weight / (2 + rank) over the lists it appears in, with
+// ranks counted from zero as the server does; lists without a weight count as one and
+// lists weighted zero are left out
+func FuseRRF(results [][]ScoredPoint, weights []float64) []ScoredPoint {
+	return fuse(results, weights, func(points []ScoredPoint) []float64 {
+		scores := make([]float64, len(points))
+		for rank := range points {
+			scores[rank] = 1 / float64(rrfK+rank)
+		}
+		return scores
+	})
+}
+
+// FuseDBSF combines result lists with weighted distribution-based score fusion
+// The scores of each list are normalized using its mean plus or minus three
+// standard deviations as limits, then weighted and summed; lists without a weight count
+// as one and lists weighted zero are left out
+func FuseDBSF(results [][]ScoredPoint, weights []float64) []ScoredPoint {
+	return fuse(results, weights, func(points []ScoredPoint) []float64 {
+		scores := make([]float64, len(points))
+		if len(points) == 0 {
+			return scores
+		}
+
+		var mean float64
+		for _, point := range points {
+			mean += float64(point.Score)
+		}
+		mean /= float64(len(points))
+
+		var variance float64
+		for _, point := range points {
+			d := float64(point.Score) - mean
+			variance += d * d
+		}
+		std := math.Sqrt(variance / float64(len(points)))
+
+		lower := mean - 3*std
+		upper := mean + 3*std
+		for i, point := range points {
+			normalized := 0.5
+			if upper > lower {
+				normalized = (float64(point.Score) - lower) / (upper - lower)
+			}
+			scores[i] = normalized
+		}
+		return scores
+	})
+}
+
+// fuse sums the weighted per-list scores of every point and returns the points by
+// descending fused score; a list beyond the weights has weight one
+func fuse(results [][]ScoredPoint, weights []float64, score func(points []ScoredPoint) []float64) []ScoredPoint {
+	fused := make(map[string]float64)
+	var order []ScoredPoint
+
+	for list, points := range results {
+		weight := 1.0
+		if list < len(weights) {
+			weight = weights[list]
+		}
+		if weight == 0 {
+			continue
+		}
+
+		scores := score(points)
+		for i, point := range points {
+			key := point.ID.String()
+			if _, ok := fused[key]; !ok {
+				order = append(order, point)
+			}
+			fused[key] += weight * scores[i]
+		}
+	}
+
+	for i := range order {
+		order[i].Score = float32(fused[order[i].ID.String()])
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Score > order[j].Score
+	})
+	return order
+}
@@ -0,0 +1,301 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FieldType represents the type of a payload index
+type FieldType string
+
+const (
+	FieldTypeKeyword  FieldType = "keyword"
+	FieldTypeInteger  FieldType = "integer"
+	FieldTypeFloat    FieldType = "float"
+	FieldTypeBool     FieldType = "bool"
+	FieldTypeGeo      FieldType = "geo"
+	FieldTypeDatetime FieldType = "datetime"
+	FieldTypeText     FieldType = "text"
+	FieldTypeUUID     FieldType = "uuid"
+)
+
+// TokenizerType selects how a full-text index splits text into tokens
+type TokenizerType string
+
+const (
+	TokenizerWord         TokenizerType = "word"
+	TokenizerWhitespace   TokenizerType = "whitespace"
+	TokenizerPrefix       TokenizerType = "prefix"
+	TokenizerMultilingual TokenizerType = "multilingual"
+)
+
+// StemmerParams represents the stemming algorithm of a full-text index
+type StemmerParams struct {
+	Type     string `json:"type"`
+	Language string `json:"language"`
+}
+
+// NewSnowballStemmer creates a snowball stemmer for the given language, such as "english"
+func NewSnowballStemmer(language string) *StemmerParams {
+	return &StemmerParams{Type: "snowball", Language: language}
+}
+
+// StopwordsConfig represents the stopwords removed by a full-text index, given as
+// predefined language lists, a custom list, or both
+type StopwordsConfig struct {
+	Languages []string
+	Custom    []string
+}
+
+// NewStopwordsLanguage creates a stopwords config using the predefined list of a language
+func NewStopwordsLanguage(language string) *StopwordsConfig {
+	return &StopwordsConfig{Languages: []string{language}}
+}
+
+// NewStopwordsCustom creates a stopwords config from a custom list of words
+func NewStopwordsCustom(words ...string) *StopwordsConfig {
+	return &StopwordsConfig{Custom: words}
+}
+
+// MarshalJSON encodes a single language as a plain string and anything else as an object
+func (s StopwordsConfig) MarshalJSON() ([]byte, error) {
+	if len(s.Languages) == 1 && len(s.Custom) == 0 {
+		return json.Marshal(s.Languages[0])
+	}
+
+	return json.Marshal(struct {
+		Languages []string `json:"languages,omitempty"`
+		Custom    []string `json:"custom,omitempty"`
+	}{
+		Languages: s.Languages,
+		Custom:    s.Custom,
+	})
+}
+
+// UnmarshalJSON decodes stopwords given as a language name or as an object
+func (s *StopwordsConfig) UnmarshalJSON(data []byte) error {
+	*s = StopwordsConfig{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var language string
+		if err := json.Unmarshal(data, &language); err != nil {
+			return err
+		}
+		s.Languages = []string{language}
+		return nil
+	}
+
+	var config struct {
+		Languages []string `json:"languages"`
+		Custom    []string `json:"custom"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("stopwords must be a language or an object: %w", err)
+	}
+	s.Languages = config.Languages
+	s.Custom = config.Custom
+	return nil
+}
+
+// TextIndexParams represents the configuration of a full-text payload index
+type TextIndexParams struct {
+	Tokenizer      TokenizerType    `json:"tokenizer,omitempty"`
+	MinTokenLen    *uint64          `json:"min_token_len,omitempty"`
+	MaxTokenLen    *uint64          `json:"max_token_len,omitempty"`
+	Lowercase      *bool            `json:"lowercase,omitempty"`
+	ASCIIFolding   *bool            `json:"ascii_folding,omitempty"`
+	Stemmer        *StemmerParams   `json:"stemmer,omitempty"`
+	Stopwords      *StopwordsConfig `json:"stopwords,omitempty"`
+	PhraseMatching *bool            `json:"phrase_matching,omitempty"`
+	OnDisk         *bool            `json:"on_disk,omitempty"`
+}
+
+// validate checks the parameters before they are sent
+func (p *TextIndexParams) validate() error {
+	switch p.Tokenizer {
+	case "", TokenizerWord, TokenizerWhitespace, TokenizerPrefix, TokenizerMultilingual:
+	default:
+		return fmt.Errorf("unsupported tokenizer %q", p.Tokenizer)
+	}
+
+	if p.MinTokenLen != nil && p.MaxTokenLen != nil && *p.MinTokenLen > *p.MaxTokenLen {
+		return fmt.Errorf("min_token_len must not exceed max_token_len")
+	}
+
+	if p.Stemmer != nil && p.Stemmer.Language == "" {
+		return fmt.Errorf("stemmer language must not be empty")
+	}
+
+	if p.Stopwords != nil && len(p.Stopwords.Languages) == 0 && len(p.Stopwords.Custom) == 0 {
+		return fmt.Errorf("stopwords must name a language or list custom words")
+	}
+	return nil
+}
+
+// FieldSchema represents the schema of a payload index, either a plain type or
+// a full-text index with its parameters
+type FieldSchema struct {
+	Type FieldType
+	Text *TextIndexParams
+}
+
+// NewFieldSchema creates a schema for an index of the given type with default parameters
+func NewFieldSchema(fieldType FieldType) *FieldSchema {
+	return &FieldSchema{Type: fieldType}
+}
+
+// NewTextFieldSchema creates a schema for a full-text index
+func NewTextFieldSchema(params TextIndexParams) *FieldSchema {
+	return &FieldSchema{Type: FieldTypeText, Text: &params}
+}
+
+// MarshalJSON encodes the schema as a type name or a parameters object
+func (s FieldSchema) MarshalJSON() ([]byte, error) {
+	if s.Text != nil {
+		return json.Marshal(struct {
+			Type FieldType `json:"type"`
+			*TextIndexParams
+		}{
+			Type:            FieldTypeText,
+			TextIndexParams: s.Text,
+		})
+	}
+	return json.Marshal(s.Type)
+}
+
+// UnmarshalJSON decodes a schema given as a type name or as a parameters object
+// Only the parameters of full-text indexes are kept
+func (s *FieldSchema) UnmarshalJSON(data []byte) error {
+	*s = FieldSchema{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &s.Type)
+	}
+
+	var probe struct {
+		Type FieldType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return fmt.Errorf("field schema must be a type or an object: %w", err)
+	}
+	if probe.Type == "" {
+		return fmt.Errorf("field schema object must have a type")
+	}
+
+	s.Type = probe.Type
+	if probe.Type == FieldTypeText {
+		s.Text = &TextIndexParams{}
+		return json.Unmarshal(data, s.Text)
+	}
+	return nil
+}
+
+// CreateFieldIndexRequest represents the request body for creating a payload index
+type CreateFieldIndexRequest struct {
+	FieldName   string       `json:"field_name"`
+	FieldSchema *FieldSchema `json:"field_schema,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *CreateFieldIndexRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := validateKeyPath(r.FieldName); err != nil {
+		return fmt.Errorf("field name: %w", err)
+	}
+
+	if r.FieldSchema != nil && r.FieldSchema.Text != nil {
+		if err := r.FieldSchema.Text.validate(); err != nil {
+			return fmt.Errorf("text index: %w", err)
+		}
+	}
+	return nil
+}
+
+// FieldIndexResponse represents the response from creating or deleting a payload index
+type FieldIndexResponse struct {
+	Usage  *Usage       `json:"usage"`
+	Time   float64      `json:"time"`
+	Status string       `json:"status"`
+	Result UpdateResult `json:"result"`
+}
+
+// CreateFieldIndex creates an index on a payload field
+func (c *Client) CreateFieldIndex(ctx context.Context, collectionName string, request *CreateFieldIndexRequest) (*FieldIndexResponse, error) {
+	path := fmt.Sprintf("/collections/%s/index", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response FieldIndexResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteFieldIndex deletes the index of a payload field
+func (c *Client) DeleteFieldIndex(ctx context.Context, collectionName string, fieldName string, options WriteOptions) (*FieldIndexResponse, error) {
+	path := fmt.Sprintf("/collections/%s/index/%s", collectionName, fieldName) + options.query()
+
+	if err := options.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response FieldIndexResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
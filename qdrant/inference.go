@@ -0,0 +1,65 @@
+package qdrant
+
+import (
+	"fmt"
+)
+
+// Document represents text that the server turns into a vector with the given model
+type Document struct {
+	Text    string                 `json:"text"`
+	Model   string                 `json:"model"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// Image represents an image, given as a URL or base64 data, that the server turns
+// into a vector with the given model
+type Image struct {
+	Image   string                 `json:"image"`
+	Model   string                 `json:"model"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// InferenceObject represents an arbitrary model input that the server turns into a vector
+type InferenceObject struct {
+	Object  interface{}            `json:"object"`
+	Model   string                 `json:"model"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// ModelUsage represents the resources used by a single inference model
+type ModelUsage struct {
+	Tokens uint64 `json:"tokens"`
+}
+
+// validate checks the document before it is sent
+func (d *Document) validate() error {
+	if d.Model == "" {
+		return fmt.Errorf("document model must not be empty")
+	}
+	if d.Text == "" {
+		return fmt.Errorf("document text must not be empty")
+	}
+	return nil
+}
+
+// validate checks the image before it is sent
+func (i *Image) validate() error {
+	if i.Model == "" {
+		return fmt.Errorf("image model must not be empty")
+	}
+	if i.Image == "" {
+		return fmt.Errorf("image must not be empty")
+	}
+	return nil
+}
+
+// validate checks the inference object before it is sent
+func (o *InferenceObject) validate() error {
+	if o.Model == "" {
+		return fmt.Errorf("inference object model must not be empty")
+	}
+	if o.Object == nil {
+		return fmt.Errorf("inference object must not be nil")
+	}
+	return nil
+}
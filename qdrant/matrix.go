@@ -0,0 +1,216 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SearchMatrixRequest represents the request body for building a distance matrix
+// Sample points are chosen from those matching the filter, and each of them is
+// paired with its Limit nearest neighbours from the same sample
+type SearchMatrixRequest struct {
+	Filter *Filter `json:"filter,omitempty"`
+	Sample uint64  `json:"sample,omitempty"`
+	Limit  uint64  `json:"limit,omitempty"`
+	Using  string  `json:"using,omitempty"`
+}
+
+// validate checks the request before it is sent
+func (r *SearchMatrixRequest) validate() error {
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	return nil
+}
+
+// SearchMatrixPair represents a single pair of points and their similarity score
+type SearchMatrixPair struct {
+	A     PointID `json:"a"`
+	B     PointID `json:"b"`
+	Score float32 `json:"score"`
+}
+
+// SearchMatrixPairs represents a distance matrix as a list of pairs
+type SearchMatrixPairs struct {
+	Pairs []SearchMatrixPair `json:"pairs"`
+}
+
+// SearchMatrixOffsets represents a distance matrix as a sparse matrix in coordinate format
+// Entry i has score Scores[i] at row OffsetsRow[i] and column OffsetsCol[i], and
+// rows and columns index into IDs
+type SearchMatrixOffsets struct {
+	OffsetsRow []uint64  `json:"offsets_row"`
+	OffsetsCol []uint64  `json:"offsets_col"`
+	Scores     []float32 `json:"scores"`
+	IDs        []PointID `json:"ids"`
+}
+
+// MatrixEntry represents a single non-empty cell of a distance matrix
+type MatrixEntry struct {
+	Row   int
+	Col   int
+	Score float32
+}
+
+// Dim returns the number of rows and columns of the square matrix
+func (m *SearchMatrixOffsets) Dim() int {
+	return len(m.IDs)
+}
+
+// Len returns the number of non-empty cells
+func (m *SearchMatrixOffsets) Len() int {
+	return len(m.Scores)
+}
+
+// Entry returns the i-th non-empty cell
+func (m *SearchMatrixOffsets) Entry(i int) MatrixEntry {
+	return MatrixEntry{
+		Row:   int(m.OffsetsRow[i]),
+		Col:   int(m.OffsetsCol[i]),
+		Score: m.Scores[i],
+	}
+}
+
+// Rows groups the non-empty cells by row, which is the adjacency list form
+// expected by most graph clustering algorithms
+func (m *SearchMatrixOffsets) Rows() [][]MatrixEntry {
+	rows := make([][]MatrixEntry, m.Dim())
+	for i := 0; i < m.Len(); i++ {
+		entry := m.Entry(i)
+		rows[entry.Row] = append(rows[entry.Row], entry)
+	}
+	return rows
+}
+
+// Dense expands the matrix into a full Dim x Dim slice, using fill for empty cells
+func (m *SearchMatrixOffsets) Dense(fill float32) [][]float32 {
+	n := m.Dim()
+	dense := make([][]float32, n)
+	for i := range dense {
+		dense[i] = make([]float32, n)
+		for j := range dense[i] {
+			dense[i][j] = fill
+		}
+	}
+	for i := 0; i < m.Len(); i++ {
+		entry := m.Entry(i)
+		dense[entry.Row][entry.Col] = entry.Score
+	}
+	return dense
+}
+
+// validate checks that all offsets are consistent with each other
+func (m *SearchMatrixOffsets) validate() error {
+	if len(m.OffsetsRow) != len(m.Scores) || len(m.OffsetsCol) != len(m.Scores) {
+		return fmt.Errorf("offsets_row, offsets_col and scores must have the same length")
+	}
+
+	n := uint64(len(m.IDs))
+	for i := range m.Scores {
+		if m.OffsetsRow[i] >= n || m.OffsetsCol[i] >= n {
+			return fmt.Errorf("offset %d is out of range for %d ids", i, n)
+		}
+	}
+	return nil
+}
+
+// SearchMatrixPairsResponse represents the response from building a distance matrix as pairs
+type SearchMatrixPairsResponse struct {
+	Usage  *Usage            `json:"usage"`
+	Time   float64           `json:"time"`
+	Status string            `json:"status"`
+	Result SearchMatrixPairs `json:"result"`
+}
+
+// SearchMatrixOffsetsResponse represents the response from building a distance matrix as offsets
+type SearchMatrixOffsetsResponse struct {
+	Usage  *Usage              `json:"usage"`
+	Time   float64             `json:"time"`
+	Status string              `json:"status"`
+	Result SearchMatrixOffsets `json:"result"`
+}
+
+// SearchMatrixPairs computes the distance matrix of a sample of points and returns it as pairs
+func (c *Client) SearchMatrixPairs(ctx context.Context, collectionName string, request *SearchMatrixRequest) (*SearchMatrixPairsResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/search/matrix/pairs", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response SearchMatrixPairsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// SearchMatrixOffsets computes the distance matrix of a sample of points and returns it in sparse form
+func (c *Client) SearchMatrixOffsets(ctx context.Context, collectionName string, request *SearchMatrixRequest) (*SearchMatrixOffsetsResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/search/matrix/offsets", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response SearchMatrixOffsetsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if err := response.Result.validate(); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
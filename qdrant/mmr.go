@@ -0,0 +1,227 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// MMR represents the maximal marginal relevance parameters of a nearest query
+// Diversity ranges from 0, which ranks by relevance only, to 1, which ranks by diversity only
+type MMR struct {
+	Diversity       *float64 `json:"diversity,omitempty"`
+	CandidatesLimit *uint64  `json:"candidates_limit,omitempty"`
+}
+
+// defaultMMRLambda is the relevance weight used when none is set, matching the server's
+// default diversity of 0.5
+const defaultMMRLambda = 0.5
+
+// NewQueryNearestMMR creates a nearest neighbours query whose results are diversified by the server
+func NewQueryNearestMMR(vector *VectorInput, mmr MMR) *Query {
+	return &Query{Nearest: vector, MMR: &mmr}
+}
+
+// MMRRequest represents a nearest neighbours search with diversified results
+// Lambda weighs relevance against diversity, from 0 (diversity only) to 1 (relevance only),
+// and defaults to 0.5 when nil
+type MMRRequest struct {
+	Vector          []float32
+	Using           string
+	Filter          *Filter
+	Params          *SearchParams
+	Limit           uint64
+	CandidatesLimit uint64
+	Lambda          *float64
+	WithPayload     *PayloadSelector
+	ForceClientMMR  bool
+}
+
+// MMRResult contains the diversified results of a search
+type MMRResult struct {
+	Points     []ScoredPoint
+	ServerSide bool
+}
+
+// validate checks the request before anything is sent
+func (r *MMRRequest) validate() error {
+	if len(r.Vector) == 0 {
+		return fmt.Errorf("mmr search requires a vector")
+	}
+	if r.Limit == 0 {
+		return fmt.Errorf("mmr search requires a positive limit")
+	}
+	if r.Lambda != nil && (*r.Lambda < 0 || *r.Lambda > 1) {
+		return fmt.Errorf("lambda must be between 0 and 1")
+	}
+	if r.CandidatesLimit != 0 && r.CandidatesLimit < r.Limit {
+		return fmt.Errorf("candidates limit must not be lower than limit")
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return nil
+}
+
+// SearchMMR finds points close to the vector while avoiding near-duplicate results
+// Servers that support MMR diversify the results themselves; on older servers, or when
+// the server version cannot be read, the candidates are fetched with their vectors and
+// reranked on the client
+func (c *Client) SearchMMR(ctx context.Context, collectionName string, request *MMRRequest) (*MMRResult, error) {
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	candidatesLimit := request.CandidatesLimit
+	if candidatesLimit == 0 {
+		candidatesLimit = request.Limit * 5
+	}
+
+	lambda := defaultMMRLambda
+	if request.Lambda != nil {
+		lambda = *request.Lambda
+	}
+
+	if !request.ForceClientMMR {
+		if version, err := c.serverVersion(ctx); err == nil && version.AtLeast(1, 15) {
+			diversity := 1 - lambda
+			response, err := c.Query(ctx, collectionName, &QueryRequest{
+				Query: NewQueryNearestMMR(NewVectorInputDense(request.Vector), MMR{
+					Diversity:       &diversity,
+					CandidatesLimit: &candidatesLimit,
+				}),
+				Using:       request.Using,
+				Filter:      request.Filter,
+				Params:      request.Params,
+				Limit:       request.Limit,
+				WithPayload: request.WithPayload,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &MMRResult{Points: response.Result.Points, ServerSide: true}, nil
+		}
+	}
+
+	withVector := NewWithVector(true)
+	if request.Using != "" {
+		withVector = NewWithVectors(request.Using)
+	}
+
+	response, err := c.Search(ctx, collectionName, &SearchRequest{
+		Vector:      NamedVector{Name: request.Using, Dense: request.Vector},
+		Filter:      request.Filter,
+		Params:      request.Params,
+		Limit:       candidatesLimit,
+		WithPayload: request.WithPayload,
+		WithVector:  withVector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := RerankMMR(request.Vector, response.Result, request.Using, lambda, int(request.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MMRResult{Points: points}, nil
+}
+
+// RerankMMR selects up to k candidates by maximal marginal relevance
+// Each step picks the candidate maximizing lambda * sim(query, candidate) minus
+// (1 - lambda) * the highest similarity to an already selected candidate, using
+// cosine similarity on the dense vector stored under vectorName; the returned
+// points keep their original scores
+func RerankMMR(query []float32, candidates []ScoredPoint, vectorName string, lambda float64, k int) ([]ScoredPoint, error) {
+	if lambda < 0 || lambda > 1 {
+		return nil, fmt.Errorf("lambda must be between 0 and 1")
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive")
+	}
+
+	vectors := make([][]float32, len(candidates))
+	relevance := make([]float64, len(candidates))
+	for i := range candidates {
+		vector, ok := candidates[i].DenseVector(vectorName)
+		if !ok {
+			return nil, fmt.Errorf("point %s has no dense vector %q", candidates[i].ID, vectorName)
+		}
+		if len(vector) != len(query) {
+			return nil, fmt.Errorf("point %s has vector dimension %d, expected %d", candidates[i].ID, len(vector), len(query))
+		}
+		vectors[i] = vector
+		relevance[i] = cosineSimilarity(query, vector)
+	}
+
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+
+	selected := make([]int, 0, k)
+	used := make([]bool, len(candidates))
+	// redundancy[i] is the highest similarity of candidate i to any selected candidate
+	redundancy := make([]float64, len(candidates))
+
+	for len(selected) < k {
+		best := -1
+		bestScore := math.Inf(-1)
+		for i := range candidates {
+			if used[i] {
+				continue
+			}
+
+			score := lambda * relevance[i]
+			if len(selected) > 0 {
+				score -= (1 - lambda) * redundancy[i]
+			}
+			if score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+
+		if best == -1 {
+			// Every remaining score is NaN
+			return nil, fmt.Errorf("candidate scores are not comparable, vectors must not contain NaN")
+		}
+
+		used[best] = true
+		selected = append(selected, best)
+
+		for i := range candidates {
+			if used[i] {
+				continue
+			}
+			sim := cosineSimilarity(vectors[i], vectors[best])
+			if len(selected) == 1 || sim > redundancy[i] {
+				redundancy[i] = sim
+			}
+		}
+	}
+
+	points := make([]ScoredPoint, len(selected))
+	for i, index := range selected {
+		points[i] = candidates[index]
+	}
+	return points, nil
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors of equal length
+func cosineSimilarity(a []float32, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,245 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SetPayloadRequest represents the request body for setting or overwriting the payload of points
+// Key sets the payload under a nested key instead of at the top level
+type SetPayloadRequest struct {
+	Payload  map[string]interface{} `json:"payload"`
+	Points   []PointID              `json:"points,omitempty"`
+	Filter   *Filter                `json:"filter,omitempty"`
+	Key      string                 `json:"key,omitempty"`
+	ShardKey *ShardKeySelector      `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *SetPayloadRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if r.Payload == nil {
+		return fmt.Errorf("payload is required")
+	}
+	if r.Key != "" {
+		if err := validateKeyPath(r.Key); err != nil {
+			return err
+		}
+	}
+	return validatePointsSelector(r.Points, r.Filter)
+}
+
+// DeletePayloadRequest represents the request body for deleting payload keys from points
+type DeletePayloadRequest struct {
+	Keys     []string          `json:"keys"`
+	Points   []PointID         `json:"points,omitempty"`
+	Filter   *Filter           `json:"filter,omitempty"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *DeletePayloadRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if len(r.Keys) == 0 {
+		return fmt.Errorf("at least one key is required")
+	}
+	for _, key := range r.Keys {
+		if err := validateKeyPath(key); err != nil {
+			return err
+		}
+	}
+	return validatePointsSelector(r.Points, r.Filter)
+}
+
+// ClearPayloadRequest represents the request body for removing the whole payload of points
+type ClearPayloadRequest struct {
+	Points   []PointID         `json:"points,omitempty"`
+	Filter   *Filter           `json:"filter,omitempty"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *ClearPayloadRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	return validatePointsSelector(r.Points, r.Filter)
+}
+
+// SetPayload merges the given payload into the payload of the selected points
+func (c *Client) SetPayload(ctx context.Context, collectionName string, request *SetPayloadRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/payload", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// OverwritePayload replaces the whole payload of the selected points
+func (c *Client) OverwritePayload(ctx context.Context, collectionName string, request *SetPayloadRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/payload", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeletePayload deletes the given keys from the payload of the selected points
+func (c *Client) DeletePayload(ctx context.Context, collectionName string, request *DeletePayloadRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/payload/delete", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ClearPayload removes the whole payload of the selected points
+func (c *Client) ClearPayload(ctx context.Context, collectionName string, request *ClearPayloadRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/payload/clear", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,1016 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PointID represents a point identifier, either an unsigned integer or a UUID string
+type PointID struct {
+	Num  uint64
+	UUID string
+}
+
+// NewIDNum creates a numeric point ID
+func NewIDNum(num uint64) PointID {
+	return PointID{Num: num}
+}
+
+// NewIDUUID creates a UUID point ID
+func NewIDUUID(uuid string) PointID {
+	return PointID{UUID: uuid}
+}
+
+// String returns the textual form of the point ID
+func (id PointID) String() string {
+	if id.UUID != "" {
+		return id.UUID
+	}
+	return strconv.FormatUint(id.Num, 10)
+}
+
+// MarshalJSON encodes the point ID as a JSON number or string
+func (id PointID) MarshalJSON() ([]byte, error) {
+	if id.UUID != "" {
+		return json.Marshal(id.UUID)
+	}
+	return json.Marshal(id.Num)
+}
+
+// UnmarshalJSON decodes a point ID from a JSON number or string
+func (id *PointID) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*id = PointID{}
+		return json.Unmarshal(data, &id.UUID)
+	}
+
+	var num uint64
+	if err := json.Unmarshal(data, &num); err != nil {
+		return fmt.Errorf("point id must be an unsigned integer or a UUID: %w", err)
+	}
+	*id = PointID{Num: num}
+	return nil
+}
+
+// VectorInput represents a vector used as a search input, given either
+// explicitly, as the ID of a point whose vector should be used, or as an
+// object the server should turn into a vector
+type VectorInput struct {
+	ID       *PointID
+	Dense    []float32
+	Sparse   *SparseVector
+	Multi    [][]float32
+	Document *Document
+	Image    *Image
+	Object   *InferenceObject
+}
+
+// NewVectorInputID creates a vector input that refers to an existing point
+func NewVectorInputID(id PointID) *VectorInput {
+	return &VectorInput{ID: &id}
+}
+
+// NewVectorInputDense creates a vector input from a dense vector
+func NewVectorInputDense(vector []float32) *VectorInput {
+	return &VectorInput{Dense: vector}
+}
+
+// NewVectorInputSparse creates a vector input from a sparse vector
+func NewVectorInputSparse(vector *SparseVector) *VectorInput {
+	return &VectorInput{Sparse: vector}
+}
+
+// NewVectorInputMulti creates a vector input from a multivector
+func NewVectorInputMulti(vectors [][]float32) *VectorInput {
+	return &VectorInput{Multi: vectors}
+}
+
+// NewVectorInputDocument creates a vector input from text embedded by the server
+func NewVectorInputDocument(document *Document) *VectorInput {
+	return &VectorInput{Document: document}
+}
+
+// NewVectorInputImage creates a vector input from an image embedded by the server
+func NewVectorInputImage(image *Image) *VectorInput {
+	return &VectorInput{Image: image}
+}
+
+// NewVectorInputObject creates a vector input from an object embedded by the server
+func NewVectorInputObject(object *InferenceObject) *VectorInput {
+	return &VectorInput{Object: object}
+}
+
+// validate checks the vector input before it is sent
+func (v *VectorInput) validate() error {
+	if v == nil {
+		return nil
+	}
+
+	if v.Sparse != nil {
+		return v.Sparse.Validate()
+	}
+	if v.Multi != nil {
+		return validateMultiVector(v.Multi)
+	}
+	if v.Document != nil {
+		return v.Document.validate()
+	}
+	if v.Image != nil {
+		return v.Image.validate()
+	}
+	if v.Object != nil {
+		return v.Object.validate()
+	}
+	return nil
+}
+
+// MarshalJSON encodes the vector input in the form expected by the API
+func (v VectorInput) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.ID != nil:
+		return json.Marshal(v.ID)
+	case v.Dense != nil:
+		return json.Marshal(v.Dense)
+	case v.Sparse != nil:
+		return json.Marshal(v.Sparse)
+	case v.Multi != nil:
+		return json.Marshal(v.Multi)
+	case v.Document != nil:
+		return json.Marshal(v.Document)
+	case v.Image != nil:
+		return json.Marshal(v.Image)
+	case v.Object != nil:
+		return json.Marshal(v.Object)
+	default:
+		return nil, fmt.Errorf("vector input is empty")
+	}
+}
+
+// validateMultiVector checks that a multivector is not empty and that all of
+// its sub-vectors have the same dimension
+func validateMultiVector(vectors [][]float32) error {
+	if len(vectors) == 0 {
+		return fmt.Errorf("multivector must contain at least one vector")
+	}
+
+	dim := len(vectors[0])
+	if dim == 0 {
+		return fmt.Errorf("multivector must not contain empty vectors")
+	}
+	for i, vector := range vectors {
+		if len(vector) != dim {
+			return fmt.Errorf("multivector sub-vector %d has dimension %d, expected %d", i, len(vector), dim)
+		}
+	}
+	return nil
+}
+
+// PayloadSelector controls which payload fields are returned with points
+type PayloadSelector struct {
+	Enable  bool
+	Include []string
+	Exclude []string
+}
+
+// NewWithPayload creates a selector that returns the whole payload or none of it
+func NewWithPayload(enable bool) *PayloadSelector {
+	return &PayloadSelector{Enable: enable}
+}
+
+// NewWithPayloadInclude creates a selector that returns only the given payload keys
+func NewWithPayloadInclude(keys ...string) *PayloadSelector {
+	return &PayloadSelector{Enable: true, Include: keys}
+}
+
+// NewWithPayloadExclude creates a selector that returns all but the given payload keys
+func NewWithPayloadExclude(keys ...string) *PayloadSelector {
+	return &PayloadSelector{Enable: true, Exclude: keys}
+}
+
+// validate checks the selected key paths
+func (s *PayloadSelector) validate() error {
+	if s == nil {
+		return nil
+	}
+	if len(s.Include) > 0 && len(s.Exclude) > 0 {
+		return fmt.Errorf("include and exclude cannot be combined")
+	}
+	for _, key := range s.Include {
+		if err := validateKeyPath(key); err != nil {
+			return fmt.Errorf("include: %w", err)
+		}
+	}
+	for _, key := range s.Exclude {
+		if err := validateKeyPath(key); err != nil {
+			return fmt.Errorf("exclude: %w", err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the selector as a bool or an include/exclude object
+func (s PayloadSelector) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(s.Include) > 0:
+		return json.Marshal(map[string][]string{"include": s.Include})
+	case len(s.Exclude) > 0:
+		return json.Marshal(map[string][]string{"exclude": s.Exclude})
+	default:
+		return json.Marshal(s.Enable)
+	}
+}
+
+// VectorSelector controls which vectors are returned with points
+type VectorSelector struct {
+	Enable bool
+	Names  []string
+}
+
+// NewWithVector creates a selector that returns all vectors or none of them
+func NewWithVector(enable bool) *VectorSelector {
+	return &VectorSelector{Enable: enable}
+}
+
+// NewWithVectors creates a selector that returns only the named vectors
+func NewWithVectors(names ...string) *VectorSelector {
+	return &VectorSelector{Enable: true, Names: names}
+}
+
+// MarshalJSON encodes the selector as a bool or a list of vector names
+func (s VectorSelector) MarshalJSON() ([]byte, error) {
+	if len(s.Names) > 0 {
+		return json.Marshal(s.Names)
+	}
+	return json.Marshal(s.Enable)
+}
+
+// LookupLocation points to another collection to look up vectors referenced by ID
+type LookupLocation struct {
+	Collection string `json:"collection"`
+	Vector     string `json:"vector,omitempty"`
+}
+
+// ScoredPoint represents a point returned by a search together with its score
+type ScoredPoint struct {
+	ID         PointID                `json:"id"`
+	Version    uint64                 `json:"version"`
+	Score      float32                `json:"score"`
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+	Vector     interface{}            `json:"vector,omitempty"`
+	ShardKey   *ShardKey              `json:"shard_key,omitempty"`
+	OrderValue interface{}            `json:"order_value,omitempty"`
+}
+
+// Record represents a stored point without a score
+// OrderValue is only set when points are ordered by a payload key, and keeps the
+// exact number so it can be sent back as a start_from cursor
+type Record struct {
+	ID         PointID                `json:"id"`
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+	Vector     interface{}            `json:"vector,omitempty"`
+	ShardKey   *ShardKey              `json:"shard_key,omitempty"`
+	OrderValue json.Number            `json:"order_value,omitempty"`
+}
+
+// DenseVector returns the dense vector of the point stored under name, or the
+// unnamed vector when name is empty
+// It reports false when the vector was not returned or is not a dense vector
+func (p *ScoredPoint) DenseVector(name string) ([]float32, bool) {
+	return denseVectorFrom(p.Vector, name)
+}
+
+// denseVectorFrom extracts a dense vector from a decoded vector field
+func denseVectorFrom(vector interface{}, name string) ([]float32, bool) {
+	if named, ok := vector.(map[string]interface{}); ok {
+		vector, ok = named[name]
+		if !ok {
+			return nil, false
+		}
+	} else if name != "" {
+		return nil, false
+	}
+
+	if dense, ok := vector.([]float32); ok {
+		return dense, true
+	}
+
+	values, ok := vector.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	dense := make([]float32, len(values))
+	for i, value := range values {
+		f, ok := value.(float64)
+		if !ok {
+			return nil, false
+		}
+		dense[i] = float32(f)
+	}
+	return dense, true
+}
+
+// Vector represents a vector value stored in a point, or an object the server
+// should turn into one
+// Exactly one of the fields should be set
+type Vector struct {
+	Dense    []float32
+	Sparse   *SparseVector
+	Multi    [][]float32
+	Document *Document
+	Image    *Image
+	Object   *InferenceObject
+}
+
+// NewVectorDense creates a dense vector value
+func NewVectorDense(vector []float32) *Vector {
+	return &Vector{Dense: vector}
+}
+
+// NewVectorSparse creates a sparse vector value
+func NewVectorSparse(vector *SparseVector) *Vector {
+	return &Vector{Sparse: vector}
+}
+
+// NewVectorMulti creates a multivector value
+func NewVectorMulti(vectors [][]float32) *Vector {
+	return &Vector{Multi: vectors}
+}
+
+// NewVectorDocument creates a vector value from text embedded by the server
+func NewVectorDocument(document *Document) *Vector {
+	return &Vector{Document: document}
+}
+
+// NewVectorImage creates a vector value from an image embedded by the server
+func NewVectorImage(image *Image) *Vector {
+	return &Vector{Image: image}
+}
+
+// NewVectorObject creates a vector value from an object embedded by the server
+func NewVectorObject(object *InferenceObject) *Vector {
+	return &Vector{Object: object}
+}
+
+// validate checks the vector value before it is sent
+func (v *Vector) validate() error {
+	switch {
+	case v.Sparse != nil:
+		return v.Sparse.Validate()
+	case v.Multi != nil:
+		return validateMultiVector(v.Multi)
+	case v.Document != nil:
+		return v.Document.validate()
+	case v.Image != nil:
+		return v.Image.validate()
+	case v.Object != nil:
+		return v.Object.validate()
+	case v.Dense != nil:
+		return nil
+	default:
+		return fmt.Errorf("vector is empty")
+	}
+}
+
+// toInput converts the vector value into a search input
+func (v *Vector) toInput() *VectorInput {
+	return &VectorInput{
+		Dense:    v.Dense,
+		Sparse:   v.Sparse,
+		Multi:    v.Multi,
+		Document: v.Document,
+		Image:    v.Image,
+		Object:   v.Object,
+	}
+}
+
+// MarshalJSON encodes the vector value in the form expected by the API
+func (v Vector) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Sparse != nil:
+		return json.Marshal(v.Sparse)
+	case v.Multi != nil:
+		return json.Marshal(v.Multi)
+	case v.Document != nil:
+		return json.Marshal(v.Document)
+	case v.Image != nil:
+		return json.Marshal(v.Image)
+	case v.Object != nil:
+		return json.Marshal(v.Object)
+	case v.Dense != nil:
+		return json.Marshal(v.Dense)
+	default:
+		return nil, fmt.Errorf("vector is empty")
+	}
+}
+
+// Vectors represents the vectors of a point, either a single unnamed vector or a set of named vectors
+type Vectors struct {
+	Unnamed *Vector
+	Named   map[string]*Vector
+}
+
+// NewVectorsUnnamed creates the vectors of a point with a single unnamed vector
+func NewVectorsUnnamed(vector *Vector) Vectors {
+	return Vectors{Unnamed: vector}
+}
+
+// NewVectorsNamed creates the vectors of a point from named vectors
+func NewVectorsNamed(named map[string]*Vector) Vectors {
+	return Vectors{Named: named}
+}
+
+// validate checks every vector of the point before it is sent
+func (v *Vectors) validate() error {
+	if v.Unnamed != nil {
+		return v.Unnamed.validate()
+	}
+
+	for name, vector := range v.Named {
+		if vector == nil {
+			return fmt.Errorf("vector %q is nil", name)
+		}
+		if err := vector.validate(); err != nil {
+			return fmt.Errorf("vector %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the vectors as a single vector or a map of named vectors
+func (v Vectors) MarshalJSON() ([]byte, error) {
+	if v.Unnamed != nil {
+		return json.Marshal(v.Unnamed)
+	}
+	if v.Named == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v.Named)
+}
+
+// PointStruct represents a point to be inserted or updated
+type PointStruct struct {
+	ID      PointID                `json:"id"`
+	Vector  Vectors                `json:"vector"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// WriteOrdering selects how an update is ordered across the replicas of a shard
+// Weak lets any replica apply it, medium routes it through a dynamically elected
+// leader and strong routes it through the permanent leader
+type WriteOrdering string
+
+const (
+	WriteOrderingWeak   WriteOrdering = "weak"
+	WriteOrderingMedium WriteOrdering = "medium"
+	WriteOrderingStrong WriteOrdering = "strong"
+)
+
+// WriteOptions controls how the server applies an update
+// With Wait the call returns once the update is applied, otherwise as soon as it is
+// acknowledged; both are sent as query parameters
+type WriteOptions struct {
+	Wait     bool
+	Ordering WriteOrdering
+}
+
+// validate checks the ordering
+func (o WriteOptions) validate() error {
+	switch o.Ordering {
+	case "", WriteOrderingWeak, WriteOrderingMedium, WriteOrderingStrong:
+		return nil
+	default:
+		return fmt.Errorf("unsupported ordering %q", o.Ordering)
+	}
+}
+
+// query returns the options as a query string, empty when no option is set
+func (o WriteOptions) query() string {
+	params := url.Values{}
+	if o.Wait {
+		params.Set("wait", "true")
+	}
+	if o.Ordering != "" {
+		params.Set("ordering", string(o.Ordering))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
+// ReadConsistencyType selects how many replicas must answer a read
+type ReadConsistencyType string
+
+const (
+	ReadConsistencyMajority ReadConsistencyType = "majority"
+	ReadConsistencyQuorum   ReadConsistencyType = "quorum"
+	ReadConsistencyAll      ReadConsistencyType = "all"
+)
+
+// ReadConsistency controls how many replicas of each shard are queried by a read
+// and must agree on the result, either as a fixed number or as a consistency type;
+// it is sent as a query parameter
+type ReadConsistency struct {
+	Factor uint64
+	Type   ReadConsistencyType
+}
+
+// NewReadConsistencyFactor creates a read consistency requiring answers from factor replicas
+func NewReadConsistencyFactor(factor uint64) *ReadConsistency {
+	return &ReadConsistency{Factor: factor}
+}
+
+// NewReadConsistency creates a read consistency of the given type
+func NewReadConsistency(consistencyType ReadConsistencyType) *ReadConsistency {
+	return &ReadConsistency{Type: consistencyType}
+}
+
+// validate checks that exactly one of factor and type is set
+func (r *ReadConsistency) validate() error {
+	if r == nil {
+		return nil
+	}
+	switch r.Type {
+	case "":
+		if r.Factor == 0 {
+			return fmt.Errorf("read consistency factor must be positive")
+		}
+	case ReadConsistencyMajority, ReadConsistencyQuorum, ReadConsistencyAll:
+		if r.Factor != 0 {
+			return fmt.Errorf("read consistency factor and type cannot be combined")
+		}
+	default:
+		return fmt.Errorf("unsupported read consistency %q", r.Type)
+	}
+	return nil
+}
+
+// query returns the consistency as a query string, empty when it is not set
+func (r *ReadConsistency) query() string {
+	if r == nil {
+		return ""
+	}
+
+	value := string(r.Type)
+	if value == "" {
+		value = strconv.FormatUint(r.Factor, 10)
+	}
+	return "?" + url.Values{"consistency": {value}}.Encode()
+}
+
+// validatePointsSelector checks that points are selected either by ID or by filter
+func validatePointsSelector(points []PointID, filter *Filter) error {
+	if len(points) > 0 && filter != nil {
+		return fmt.Errorf("points and filter cannot be combined")
+	}
+	if len(points) == 0 && filter == nil {
+		return fmt.Errorf("points or filter is required")
+	}
+	if err := filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	return nil
+}
+
+// UpsertPointsRequest represents the request body for inserting or updating points
+type UpsertPointsRequest struct {
+	Points   []PointStruct     `json:"points"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks every point before the request is sent
+func (r *UpsertPointsRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	for i := range r.Points {
+		if err := r.Points[i].Vector.validate(); err != nil {
+			return fmt.Errorf("point %s: %w", r.Points[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// UpsertPointsResponse represents the response from inserting or updating points
+type UpsertPointsResponse struct {
+	Usage  *Usage       `json:"usage"`
+	Time   float64      `json:"time"`
+	Status string       `json:"status"`
+	Result UpdateResult `json:"result"`
+}
+
+// UpsertPoints inserts new points or replaces existing points with the same IDs
+func (c *Client) UpsertPoints(ctx context.Context, collectionName string, request *UpsertPointsRequest) (*UpsertPointsResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpsertPointsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// UpdateStatus represents the status of an update operation
+// Acknowledged updates are queued, completed updates are applied; the server only
+// waits for completion when the request sets Wait
+type UpdateStatus string
+
+const (
+	UpdateStatusAcknowledged UpdateStatus = "acknowledged"
+	UpdateStatusCompleted    UpdateStatus = "completed"
+)
+
+// UpdateResult represents the result of an update operation
+type UpdateResult struct {
+	OperationID *uint64      `json:"operation_id,omitempty"`
+	Status      UpdateStatus `json:"status"`
+}
+
+// UpdateResponse represents the response from an operation that updates points or their payload
+type UpdateResponse struct {
+	Usage  *Usage       `json:"usage"`
+	Time   float64      `json:"time"`
+	Status string       `json:"status"`
+	Result UpdateResult `json:"result"`
+}
+
+// DeletePointsRequest represents the request body for deleting points by ID or by filter
+type DeletePointsRequest struct {
+	Points   []PointID         `json:"points,omitempty"`
+	Filter   *Filter           `json:"filter,omitempty"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *DeletePointsRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	return validatePointsSelector(r.Points, r.Filter)
+}
+
+// DeletePoints deletes the selected points
+func (c *Client) DeletePoints(ctx context.Context, collectionName string, request *DeletePointsRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/delete", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// PointVectors represents the vectors to store for an existing point
+type PointVectors struct {
+	ID     PointID `json:"id"`
+	Vector Vectors `json:"vector"`
+}
+
+// UpdateVectorsRequest represents the request body for updating the vectors of existing points
+type UpdateVectorsRequest struct {
+	Points   []PointVectors    `json:"points"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks every vector before the request is sent
+func (r *UpdateVectorsRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	for i := range r.Points {
+		if err := r.Points[i].Vector.validate(); err != nil {
+			return fmt.Errorf("point %s: %w", r.Points[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// UpdateVectors replaces the given vectors of existing points, keeping their other vectors
+func (c *Client) UpdateVectors(ctx context.Context, collectionName string, request *UpdateVectorsRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/vectors", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteVectorsRequest represents the request body for deleting named vectors from points
+type DeleteVectorsRequest struct {
+	Points   []PointID         `json:"points,omitempty"`
+	Filter   *Filter           `json:"filter,omitempty"`
+	Vector   []string          `json:"vector"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	WriteOptions `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *DeleteVectorsRequest) validate() error {
+	if err := r.WriteOptions.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if len(r.Vector) == 0 {
+		return fmt.Errorf("at least one vector name is required")
+	}
+	return validatePointsSelector(r.Points, r.Filter)
+}
+
+// DeleteVectors deletes the named vectors from the selected points
+func (c *Client) DeleteVectors(ctx context.Context, collectionName string, request *DeleteVectorsRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/vectors/delete", collectionName) + request.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response UpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetPointsRequest represents the request body for retrieving points by ID
+type GetPointsRequest struct {
+	IDs         []PointID         `json:"ids"`
+	WithPayload *PayloadSelector  `json:"with_payload,omitempty"`
+	WithVector  *VectorSelector   `json:"with_vector,omitempty"`
+	ShardKey    *ShardKeySelector `json:"shard_key,omitempty"`
+
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *GetPointsRequest) validate() error {
+	if len(r.IDs) == 0 {
+		return fmt.Errorf("at least one point ID is required")
+	}
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return nil
+}
+
+// GetPointsResponse represents the response from retrieving points by ID
+// Points that do not exist are left out of the result
+type GetPointsResponse struct {
+	Usage  *Usage   `json:"usage"`
+	Time   float64  `json:"time"`
+	Status string   `json:"status"`
+	Result []Record `json:"result"`
+}
+
+// GetPoints retrieves the points with the given IDs
+func (c *Client) GetPoints(ctx context.Context, collectionName string, request *GetPointsRequest) (*GetPointsResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response GetPointsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// CountPointsRequest represents the request body for counting points
+// Without Exact the count is an estimate that is cheaper to compute
+type CountPointsRequest struct {
+	Filter   *Filter           `json:"filter,omitempty"`
+	Exact    *bool             `json:"exact,omitempty"`
+	ShardKey *ShardKeySelector `json:"shard_key,omitempty"`
+
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *CountPointsRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	return nil
+}
+
+// CountResult contains the number of points matching the filter
+type CountResult struct {
+	Count uint64 `json:"count"`
+}
+
+// CountPointsResponse represents the response from counting points
+type CountPointsResponse struct {
+	Usage  *Usage      `json:"usage"`
+	Time   float64     `json:"time"`
+	Status string      `json:"status"`
+	Result CountResult `json:"result"`
+}
+
+// CountPoints counts the points matching the filter
+func (c *Client) CountPoints(ctx context.Context, collectionName string, request *CountPointsRequest) (*CountPointsResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/count", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response CountPointsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
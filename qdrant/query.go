@@ -0,0 +1,363 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Fusion selects the algorithm used to combine the results of several prefetches
+type Fusion string
+
+const (
+	FusionRRF  Fusion = "rrf"
+	FusionDBSF Fusion = "dbsf"
+)
+
+// Sample selects how points are sampled by a sample query
+type Sample string
+
+const (
+	SampleRandom Sample = "random"
+)
+
+// Direction selects the order of an order by query or scroll
+type Direction string
+
+const (
+	DirectionAsc  Direction = "asc"
+	DirectionDesc Direction = "desc"
+)
+
+// OrderBy orders points by the values of a payload key
+// The key must have an integer, float or datetime payload index; StartFrom is an
+// inclusive bound given as a number, a time.Time or an RFC 3339 string
+type OrderBy struct {
+	Key       string      `json:"key"`
+	Direction Direction   `json:"direction,omitempty"`
+	StartFrom interface{} `json:"start_from,omitempty"`
+}
+
+// validate checks the key and direction
+func (o *OrderBy) validate() error {
+	if o == nil {
+		return nil
+	}
+	if err := validateKeyPath(o.Key); err != nil {
+		return err
+	}
+	switch o.Direction {
+	case "", DirectionAsc, DirectionDesc:
+	default:
+		return fmt.Errorf("unsupported direction %q", o.Direction)
+	}
+	return nil
+}
+
+// RecommendInput represents the examples of a recommendation query
+type RecommendInput struct {
+	Positive []VectorInput     `json:"positive,omitempty"`
+	Negative []VectorInput     `json:"negative,omitempty"`
+	Strategy RecommendStrategy `json:"strategy,omitempty"`
+}
+
+// DiscoverInput represents the target and context of a discovery query
+type DiscoverInput struct {
+	Target  VectorInput   `json:"target"`
+	Context []ContextPair `json:"context"`
+}
+
+// Query represents a universal query
+// Only one kind of query should be set at a time
+type Query struct {
+	Nearest   *VectorInput           `json:"nearest,omitempty"`
+	MMR       *MMR                   `json:"mmr,omitempty"`
+	Recommend *RecommendInput        `json:"recommend,omitempty"`
+	Discover  *DiscoverInput         `json:"discover,omitempty"`
+	Context   []ContextPair          `json:"context,omitempty"`
+	Fusion    Fusion                 `json:"fusion,omitempty"`
+	Formula   *Expression            `json:"formula,omitempty"`
+	Defaults  map[string]interface{} `json:"defaults,omitempty"`
+	OrderBy   *OrderBy               `json:"order_by,omitempty"`
+	Sample    Sample                 `json:"sample,omitempty"`
+}
+
+// validate checks the vectors of the query before it is sent
+func (q *Query) validate() error {
+	if q == nil {
+		return nil
+	}
+
+	if err := q.Nearest.validate(); err != nil {
+		return fmt.Errorf("nearest: %w", err)
+	}
+	if q.MMR != nil {
+		if q.Nearest == nil {
+			return fmt.Errorf("mmr requires a nearest query")
+		}
+		if d := q.MMR.Diversity; d != nil && (*d < 0 || *d > 1) {
+			return fmt.Errorf("mmr diversity must be between 0 and 1")
+		}
+	}
+	if q.Recommend != nil {
+		if err := validateExamples(q.Recommend.Positive, q.Recommend.Negative); err != nil {
+			return fmt.Errorf("recommend: %w", err)
+		}
+	}
+	if q.Formula != nil {
+		if err := q.Formula.validate(); err != nil {
+			return fmt.Errorf("formula: %w", err)
+		}
+	}
+	if err := q.OrderBy.validate(); err != nil {
+		return fmt.Errorf("order_by: %w", err)
+	}
+	switch q.Sample {
+	case "", SampleRandom:
+	default:
+		return fmt.Errorf("unsupported sample %q", q.Sample)
+	}
+	if q.Discover != nil {
+		if err := q.Discover.Target.validate(); err != nil {
+			return fmt.Errorf("discover target: %w", err)
+		}
+		if err := validateContext(q.Discover.Context); err != nil {
+			return fmt.Errorf("discover: %w", err)
+		}
+	}
+	return validateContext(q.Context)
+}
+
+// NewQueryNearest creates a nearest neighbours query
+func NewQueryNearest(vector *VectorInput) *Query {
+	return &Query{Nearest: vector}
+}
+
+// NewQueryRecommend creates a recommendation query
+func NewQueryRecommend(input *RecommendInput) *Query {
+	return &Query{Recommend: input}
+}
+
+// NewQueryDiscover creates a discovery query
+func NewQueryDiscover(input *DiscoverInput) *Query {
+	return &Query{Discover: input}
+}
+
+// NewQueryContext creates a context-only query
+func NewQueryContext(pairs ...ContextPair) *Query {
+	return &Query{Context: pairs}
+}
+
+// NewQueryFusion creates a query that fuses the results of the prefetches
+func NewQueryFusion(fusion Fusion) *Query {
+	return &Query{Fusion: fusion}
+}
+
+// NewQueryFormula creates a query that rescores the prefetched points with a formula
+// Defaults provides values for payload variables that are missing from a point
+func NewQueryFormula(formula Expression, defaults map[string]interface{}) *Query {
+	return &Query{Formula: &formula, Defaults: defaults}
+}
+
+// NewQueryOrderBy creates a query that returns points ordered by a payload key
+func NewQueryOrderBy(orderBy OrderBy) *Query {
+	return &Query{OrderBy: &orderBy}
+}
+
+// NewQuerySample creates a query that returns a sample of the points
+func NewQuerySample(sample Sample) *Query {
+	return &Query{Sample: sample}
+}
+
+// Prefetch represents a sub-request whose results are used as candidates by the parent query
+type Prefetch struct {
+	Prefetch       []Prefetch      `json:"prefetch,omitempty"`
+	Query          *Query          `json:"query,omitempty"`
+	Using          string          `json:"using,omitempty"`
+	Filter         *Filter         `json:"filter,omitempty"`
+	Params         *SearchParams   `json:"params,omitempty"`
+	ScoreThreshold *float32        `json:"score_threshold,omitempty"`
+	Limit          uint64          `json:"limit,omitempty"`
+	LookupFrom     *LookupLocation `json:"lookup_from,omitempty"`
+}
+
+// validate checks the prefetch and its nested prefetches before they are sent
+func (p *Prefetch) validate() error {
+	if err := p.Query.validate(); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+	if err := p.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	return validatePrefetches(p.Prefetch)
+}
+
+// validatePrefetches checks a list of prefetches
+func validatePrefetches(prefetches []Prefetch) error {
+	for i := range prefetches {
+		if err := prefetches[i].validate(); err != nil {
+			return fmt.Errorf("prefetch %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// QueryRequest represents the request body for the universal query API
+type QueryRequest struct {
+	Prefetch       []Prefetch        `json:"prefetch,omitempty"`
+	Query          *Query            `json:"query,omitempty"`
+	Using          string            `json:"using,omitempty"`
+	Filter         *Filter           `json:"filter,omitempty"`
+	Params         *SearchParams     `json:"params,omitempty"`
+	ScoreThreshold *float32          `json:"score_threshold,omitempty"`
+	Limit          uint64            `json:"limit,omitempty"`
+	Offset         uint64            `json:"offset,omitempty"`
+	WithPayload    *PayloadSelector  `json:"with_payload,omitempty"`
+	WithVector     *VectorSelector   `json:"with_vector,omitempty"`
+	LookupFrom     *LookupLocation   `json:"lookup_from,omitempty"`
+	ShardKey       *ShardKeySelector `json:"shard_key,omitempty"`
+
+	// Consistency is ignored for queries sent as part of a batch
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *QueryRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if err := r.Query.validate(); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return validatePrefetches(r.Prefetch)
+}
+
+// QueryBatchRequest represents the request body for a batch of queries
+type QueryBatchRequest struct {
+	Searches []QueryRequest `json:"searches"`
+
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks every query of the batch before it is sent
+func (r *QueryBatchRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	for i := range r.Searches {
+		if err := r.Searches[i].validate(); err != nil {
+			return fmt.Errorf("search %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// QueryResult contains the points found by a query
+type QueryResult struct {
+	Points []ScoredPoint `json:"points"`
+}
+
+// QueryResponse represents the response from a query
+type QueryResponse struct {
+	Usage  *Usage      `json:"usage"`
+	Time   float64     `json:"time"`
+	Status string      `json:"status"`
+	Result QueryResult `json:"result"`
+}
+
+// QueryBatchResponse represents the response from a batch of queries
+type QueryBatchResponse struct {
+	Usage  *Usage        `json:"usage"`
+	Time   float64       `json:"time"`
+	Status string        `json:"status"`
+	Result []QueryResult `json:"result"`
+}
+
+// Query runs a universal query, which can combine prefetches, searches and fusion
+func (c *Client) Query(ctx context.Context, collectionName string, request *QueryRequest) (*QueryResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/query", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response QueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// QueryBatch runs multiple universal queries in a single request
+func (c *Client) QueryBatch(ctx context.Context, collectionName string, request *QueryBatchRequest) (*QueryBatchResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/query/batch", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response QueryBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
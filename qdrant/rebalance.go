@@ -0,0 +1,503 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// moveStartPolls is how many polls a move may take to show up in the cluster info
+const moveStartPolls = 5
+
+// ShardMove represents moving a shard replica from one peer to another
+// Points is the number of points of the shard, estimated from the collection average
+// when the shard is not local to the peer that answered
+type ShardMove struct {
+	Collection string
+	ShardID    uint64
+	FromPeerID uint64
+	ToPeerID   uint64
+	Points     uint64
+}
+
+// String describes the move
+func (m ShardMove) String() string {
+	return fmt.Sprintf("move shard %d of %q from peer %d to peer %d (%d points)", m.ShardID, m.Collection, m.FromPeerID, m.ToPeerID, m.Points)
+}
+
+// RebalancePlan lists the moves that even out the load of the peers
+// Before and After map each peer to its load, counted in replicas or in points
+type RebalancePlan struct {
+	Moves         []ShardMove
+	BalancePoints bool
+	Before        map[uint64]uint64
+	After         map[uint64]uint64
+	// Skipped lists the collections whose replicas are not moved because a shard transfer
+	// was in progress; they still count towards the load
+	Skipped []string
+}
+
+// Print writes the plan in a human readable form
+func (p *RebalancePlan) Print(w io.Writer) error {
+	unit := "replicas"
+	if p.BalancePoints {
+		unit = "points"
+	}
+
+	if _, err := fmt.Fprintf(w, "rebalance plan: %d moves\n", len(p.Moves)); err != nil {
+		return err
+	}
+	for _, move := range p.Moves {
+		if _, err := fmt.Fprintf(w, "  %s\n", move); err != nil {
+			return err
+		}
+	}
+	for _, collection := range p.Skipped {
+		if _, err := fmt.Fprintf(w, "  skipped %q: shard transfer in progress\n", collection); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "load in %s:\n", unit); err != nil {
+		return err
+	}
+	for _, peerID := range sortedPeers(p.Before) {
+		if _, err := fmt.Fprintf(w, "  peer %d: %d -> %d\n", peerID, p.Before[peerID], p.After[peerID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RebalanceOptions controls how the rebalancer plans and executes moves
+type RebalanceOptions struct {
+	// Collections limits rebalancing to the given collections, all collections when empty
+	Collections []string
+	// BalancePoints evens out point counts instead of replica counts
+	BalancePoints bool
+	// DryRun only prints the plan
+	DryRun bool
+	// Concurrency is the number of transfers run at once, one when unset; a peer
+	// never sends or receives more than one shard at a time
+	Concurrency int
+	// Method is the transfer method, the server default when empty
+	Method ShardTransferMethod
+	// PollInterval is how often transfers are polled, one second when unset
+	PollInterval time.Duration
+	// Output receives the plan and the progress of the moves, discarded when nil
+	Output io.Writer
+}
+
+// Rebalancer moves shard replicas between peers so that every peer carries a similar load
+type Rebalancer struct {
+	client  *Client
+	options RebalanceOptions
+
+	outputMu sync.Mutex
+}
+
+// NewRebalancer creates a rebalancer using the client
+func NewRebalancer(client *Client, options RebalanceOptions) *Rebalancer {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+	if options.Output == nil {
+		options.Output = io.Discard
+	}
+	return &Rebalancer{client: client, options: options}
+}
+
+// shardRef identifies a shard of a collection
+type shardRef struct {
+	collection string
+	shardID    uint64
+}
+
+// placedReplica is an active replica the planner may move
+type placedReplica struct {
+	shard  shardRef
+	peerID uint64
+	points uint64
+	moved  bool
+}
+
+// Run plans the rebalance, prints the plan and executes it unless DryRun is set
+func (r *Rebalancer) Run(ctx context.Context) (*RebalancePlan, error) {
+	plan, err := r.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.outputMu.Lock()
+	err = plan.Print(r.options.Output)
+	r.outputMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("printing plan: %w", err)
+	}
+
+	if r.options.DryRun {
+		return plan, nil
+	}
+	return plan, r.Execute(ctx, plan)
+}
+
+// Plan reads the cluster state and computes the moves that even out the load of the peers
+// Each move shifts one active replica from the most loaded peer to the least loaded peer
+// that holds no replica of the same shard, as long as the gap between them shrinks; no
+// replica is moved twice, so the plan never chains moves
+func (r *Rebalancer) Plan(ctx context.Context) (*RebalancePlan, error) {
+	peers, err := r.client.clusterPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	load := make(map[uint64]uint64, len(peers))
+	for peerID := range peers {
+		load[peerID] = 0
+	}
+
+	collections, err := r.client.collectionNames(ctx, r.options.Collections)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RebalancePlan{BalancePoints: r.options.BalancePoints}
+	holders := make(map[shardRef]map[uint64]bool)
+	// collectionLoad counts the replicas of each collection per peer, to prefer moves
+	// that also even out individual collections
+	collectionLoad := make(map[string]map[uint64]int)
+	var movable []*placedReplica
+
+	for _, collection := range collections {
+		response, err := r.client.GetCollectionClusterInfo(ctx, collection)
+		if err != nil {
+			return nil, fmt.Errorf("getting cluster info of %q: %w", collection, err)
+		}
+		info := &response.Result
+
+		// A collection with a running transfer still adds to the load of the peers, but
+		// none of its replicas is moved
+		skipped := len(info.ShardTransfers) > 0
+		if skipped {
+			plan.Skipped = append(plan.Skipped, collection)
+		}
+
+		points, err := r.shardPoints(ctx, collection, info)
+		if err != nil {
+			return nil, err
+		}
+
+		collectionLoad[collection] = make(map[uint64]int)
+		for _, replica := range info.Replicas() {
+			ref := shardRef{collection: collection, shardID: replica.ShardID}
+			if holders[ref] == nil {
+				holders[ref] = make(map[uint64]bool)
+			}
+			holders[ref][replica.PeerID] = true
+
+			if _, ok := load[replica.PeerID]; !ok {
+				// The peer is not part of the cluster anymore
+				continue
+			}
+
+			weight := uint64(1)
+			if r.options.BalancePoints {
+				weight = points[replica.ShardID]
+			}
+			load[replica.PeerID] += weight
+			collectionLoad[collection][replica.PeerID]++
+
+			if replica.State == ReplicaStateActive && !skipped {
+				movable = append(movable, &placedReplica{shard: ref, peerID: replica.PeerID, points: points[replica.ShardID]})
+			}
+		}
+	}
+
+	plan.Before = make(map[uint64]uint64, len(load))
+	for peerID, value := range load {
+		plan.Before[peerID] = value
+	}
+
+	for {
+		replica, toPeerID, ok := r.nextMove(load, movable, holders, collectionLoad)
+		if !ok {
+			break
+		}
+
+		weight := uint64(1)
+		if r.options.BalancePoints {
+			weight = replica.points
+		}
+
+		plan.Moves = append(plan.Moves, ShardMove{
+			Collection: replica.shard.collection,
+			ShardID:    replica.shard.shardID,
+			FromPeerID: replica.peerID,
+			ToPeerID:   toPeerID,
+			Points:     replica.points,
+		})
+
+		load[replica.peerID] -= weight
+		load[toPeerID] += weight
+		collectionLoad[replica.shard.collection][replica.peerID]--
+		collectionLoad[replica.shard.collection][toPeerID]++
+		delete(holders[replica.shard], replica.peerID)
+		holders[replica.shard][toPeerID] = true
+		replica.peerID = toPeerID
+		replica.moved = true
+	}
+
+	plan.After = load
+	return plan, nil
+}
+
+// shardPoints returns the number of points of each shard of a collection
+// Shards that are not local to the peer that answered get the collection average
+func (r *Rebalancer) shardPoints(ctx context.Context, collection string, info *CollectionClusterInfo) (map[uint64]uint64, error) {
+	points := make(map[uint64]uint64)
+	if !r.options.BalancePoints {
+		return points, nil
+	}
+
+	for _, shard := range info.LocalShards {
+		points[shard.ShardID] = shard.PointsCount
+	}
+
+	var average uint64
+	averageKnown := false
+	for _, replica := range info.Replicas() {
+		if _, ok := points[replica.ShardID]; ok {
+			continue
+		}
+
+		if !averageKnown {
+			response, err := r.client.GetCollection(ctx, collection)
+			if err != nil {
+				return nil, fmt.Errorf("getting collection %q: %w", collection, err)
+			}
+			if response.Result != nil && info.ShardCount > 0 {
+				average = response.Result.PointsCount / uint64(info.ShardCount)
+			}
+			averageKnown = true
+		}
+		points[replica.ShardID] = average
+	}
+	return points, nil
+}
+
+// nextMove finds the replica whose move narrows the gap between the most and least
+// loaded peers the most, trying less extreme pairs when the extremes have no candidate
+func (r *Rebalancer) nextMove(load map[uint64]uint64, movable []*placedReplica, holders map[shardRef]map[uint64]bool, collectionLoad map[string]map[uint64]int) (*placedReplica, uint64, bool) {
+	peers := make([]uint64, 0, len(load))
+	for peerID := range load {
+		peers = append(peers, peerID)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if load[peers[i]] != load[peers[j]] {
+			return load[peers[i]] < load[peers[j]]
+		}
+		return peers[i] < peers[j]
+	})
+
+	for i := len(peers) - 1; i > 0; i-- {
+		from := peers[i]
+		for _, to := range peers[:i] {
+			if load[from] <= load[to] {
+				break
+			}
+			gap := load[from] - load[to]
+
+			var best *placedReplica
+			var bestRemaining uint64
+			var bestSkew int
+			for _, replica := range movable {
+				if replica.moved || replica.peerID != from || holders[replica.shard][to] {
+					continue
+				}
+
+				weight := uint64(1)
+				if r.options.BalancePoints {
+					weight = replica.points
+				}
+				// Only moves that strictly narrow the gap, which also guarantees termination
+				if weight == 0 || weight >= gap {
+					continue
+				}
+
+				remaining := absDiff(gap, 2*weight)
+				skew := collectionLoad[replica.shard.collection][from] - collectionLoad[replica.shard.collection][to]
+				if best == nil || remaining < bestRemaining || (remaining == bestRemaining && skew > bestSkew) {
+					best, bestRemaining, bestSkew = replica, remaining, skew
+				}
+			}
+			if best != nil {
+				return best, to, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// Execute runs the moves of a plan and waits for every transfer to finish
+// Up to Concurrency transfers run at once, while a shard is only moved once at a time and
+// a peer never sends or receives more than one shard at a time. Failed moves do not stop
+// the others and are returned together
+func (r *Rebalancer) Execute(ctx context.Context, plan *RebalancePlan) error {
+	type moveResult struct {
+		move ShardMove
+		err  error
+	}
+
+	pending := append([]ShardMove(nil), plan.Moves...)
+	busy := make(map[string]bool)
+	keys := func(move ShardMove) []string {
+		return []string{
+			fmt.Sprintf("shard %s/%d", move.Collection, move.ShardID),
+			fmt.Sprintf("from %d", move.FromPeerID),
+			fmt.Sprintf("to %d", move.ToPeerID),
+		}
+	}
+
+	results := make(chan moveResult)
+	running := 0
+	var errs []error
+
+	for len(pending) > 0 || running > 0 {
+		for i := 0; i < len(pending) && running < r.options.Concurrency && ctx.Err() == nil; {
+			move := pending[i]
+
+			free := true
+			for _, key := range keys(move) {
+				free = free && !busy[key]
+			}
+			if !free {
+				i++
+				continue
+			}
+
+			for _, key := range keys(move) {
+				busy[key] = true
+			}
+			pending = append(pending[:i], pending[i+1:]...)
+			running++
+
+			go func() {
+				results <- moveResult{move: move, err: r.executeMove(ctx, move)}
+			}()
+		}
+
+		if running == 0 {
+			// Only reached when the context is done
+			for _, move := range pending {
+				errs = append(errs, fmt.Errorf("%s: %w", move, ctx.Err()))
+			}
+			break
+		}
+
+		result := <-results
+		running--
+		for _, key := range keys(result.move) {
+			delete(busy, key)
+		}
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.move, result.err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// executeMove starts a single move and waits for its transfer to end
+func (r *Rebalancer) executeMove(ctx context.Context, move ShardMove) error {
+	r.printf("starting: %s\n", move)
+
+	if _, err := r.client.MoveShardWithMethod(ctx, move.Collection, move.ShardID, move.FromPeerID, move.ToPeerID, r.options.Method); err != nil {
+		return err
+	}
+
+	result, err := r.client.WaitForTransfer(ctx, move.Collection, move.ShardID, move.FromPeerID, move.ToPeerID, &WaitForTransferOptions{
+		PollInterval: r.options.PollInterval,
+		StartPolls:   moveStartPolls,
+		OnProgress: func(progress TransferProgress) {
+			if progress.Total > 0 {
+				r.printf("progress: %s: %d/%d\n", move, progress.Done, progress.Total)
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if result.Outcome != TransferFinished {
+		return fmt.Errorf("transfer %s with replica state %q", result.Outcome, result.State)
+	}
+
+	r.printf("finished: %s\n", move)
+	return nil
+}
+
+// printf writes progress to the output, one line at a time
+func (r *Rebalancer) printf(format string, args ...interface{}) {
+	r.outputMu.Lock()
+	defer r.outputMu.Unlock()
+
+	fmt.Fprintf(r.options.Output, format, args...)
+}
+
+// clusterPeers returns the set of peers of the cluster
+func (c *Client) clusterPeers(ctx context.Context) (map[uint64]bool, error) {
+	cluster, err := c.GetClusterInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster info: %w", err)
+	}
+
+	peers := make(map[uint64]bool, len(cluster.Result.Peers))
+	for key := range cluster.Result.Peers {
+		peerID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing peer id %q: %w", key, err)
+		}
+		peers[peerID] = true
+	}
+	return peers, nil
+}
+
+// collectionNames returns the given collections, or every collection when none is given
+func (c *Client) collectionNames(ctx context.Context, names []string) ([]string, error) {
+	if len(names) > 0 {
+		return names, nil
+	}
+
+	response, err := c.ListCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing collections: %w", err)
+	}
+	if response.Result != nil {
+		for _, collection := range response.Result.Collections {
+			names = append(names, collection.Name)
+		}
+	}
+	return names, nil
+}
+
+// sortedPeers returns the peer IDs of a load map in ascending order
+func sortedPeers(load map[uint64]uint64) []uint64 {
+	peers := make([]uint64, 0, len(load))
+	for peerID := range load {
+		peers = append(peers, peerID)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return peers
+}
+
+// absDiff returns the absolute difference of two unsigned integers
+func absDiff(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,172 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RecommendStrategy selects how positive and negative examples are combined
+type RecommendStrategy string
+
+const (
+	RecommendStrategyAverageVector RecommendStrategy = "average_vector"
+	RecommendStrategyBestScore     RecommendStrategy = "best_score"
+	RecommendStrategySumScores     RecommendStrategy = "sum_scores"
+)
+
+// RecommendRequest represents the request body for a recommendation search
+type RecommendRequest struct {
+	Positive       []VectorInput     `json:"positive,omitempty"`
+	Negative       []VectorInput     `json:"negative,omitempty"`
+	Strategy       RecommendStrategy `json:"strategy,omitempty"`
+	Filter         *Filter           `json:"filter,omitempty"`
+	Params         *SearchParams     `json:"params,omitempty"`
+	Limit          uint64            `json:"limit"`
+	Offset         uint64            `json:"offset,omitempty"`
+	WithPayload    *PayloadSelector  `json:"with_payload,omitempty"`
+	WithVector     *VectorSelector   `json:"with_vector,omitempty"`
+	ScoreThreshold *float32          `json:"score_threshold,omitempty"`
+	Using          string            `json:"using,omitempty"`
+	LookupFrom     *LookupLocation   `json:"lookup_from,omitempty"`
+}
+
+// validate checks the request before it is sent
+func (r *RecommendRequest) validate() error {
+	if err := validateExamples(r.Positive, r.Negative); err != nil {
+		return err
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return nil
+}
+
+// RecommendBatchRequest represents the request body for a batch of recommendation searches
+type RecommendBatchRequest struct {
+	Searches []RecommendRequest `json:"searches"`
+}
+
+// validate checks every search of the batch before it is sent
+func (r *RecommendBatchRequest) validate() error {
+	for i := range r.Searches {
+		if err := r.Searches[i].validate(); err != nil {
+			return fmt.Errorf("search %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateExamples checks positive and negative recommendation examples
+func validateExamples(positive []VectorInput, negative []VectorInput) error {
+	for i := range positive {
+		if err := positive[i].validate(); err != nil {
+			return fmt.Errorf("positive example %d: %w", i, err)
+		}
+	}
+	for i := range negative {
+		if err := negative[i].validate(); err != nil {
+			return fmt.Errorf("negative example %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// RecommendResponse represents the response from a recommendation search
+type RecommendResponse struct {
+	Usage  *Usage        `json:"usage"`
+	Time   float64       `json:"time"`
+	Status string        `json:"status"`
+	Result []ScoredPoint `json:"result"`
+}
+
+// RecommendBatchResponse represents the response from a batch of recommendation searches
+type RecommendBatchResponse struct {
+	Usage  *Usage          `json:"usage"`
+	Time   float64         `json:"time"`
+	Status string          `json:"status"`
+	Result [][]ScoredPoint `json:"result"`
+}
+
+// Recommend finds points similar to the positive examples and dissimilar to the negative ones
+func (c *Client) Recommend(ctx context.Context, collectionName string, request *RecommendRequest) (*RecommendResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/recommend", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response RecommendResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// RecommendBatch runs multiple recommendation searches in a single request
+func (c *Client) RecommendBatch(ctx context.Context, collectionName string, request *RecommendBatchRequest) (*RecommendBatchResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/recommend/batch", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response RecommendBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
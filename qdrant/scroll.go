@@ -0,0 +1,206 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ScrollRequest represents the request body for listing points page by page
+// Points are ordered by ID unless OrderBy is set; Offset cannot be combined with OrderBy
+type ScrollRequest struct {
+	Offset      *PointID          `json:"offset,omitempty"`
+	Limit       uint64            `json:"limit,omitempty"`
+	Filter      *Filter           `json:"filter,omitempty"`
+	WithPayload *PayloadSelector  `json:"with_payload,omitempty"`
+	WithVector  *VectorSelector   `json:"with_vector,omitempty"`
+	OrderBy     *OrderBy          `json:"order_by,omitempty"`
+	ShardKey    *ShardKeySelector `json:"shard_key,omitempty"`
+
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *ScrollRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if r.Offset != nil && r.OrderBy != nil {
+		return fmt.Errorf("offset cannot be combined with order_by, use order_by start_from instead")
+	}
+	if err := r.OrderBy.validate(); err != nil {
+		return fmt.Errorf("order_by: %w", err)
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return nil
+}
+
+// ScrollResult contains a page of points and the offset of the next page
+// NextPageOffset is nil on the last page and always nil when ordering by a payload key
+type ScrollResult struct {
+	Points         []Record `json:"points"`
+	NextPageOffset *PointID `json:"next_page_offset"`
+}
+
+// ScrollResponse represents the response from a scroll request
+type ScrollResponse struct {
+	Usage  *Usage       `json:"usage"`
+	Time   float64      `json:"time"`
+	Status string       `json:"status"`
+	Result ScrollResult `json:"result"`
+}
+
+// ScrollPoints returns a page of points matching the filter
+func (c *Client) ScrollPoints(ctx context.Context, collectionName string, request *ScrollRequest) (*ScrollResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/scroll", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response ScrollResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ScrollIterator walks through every point matching a scroll request, fetching pages as needed
+type ScrollIterator struct {
+	client         *Client
+	collectionName string
+	request        ScrollRequest
+	page           []Record
+	index          int
+	done           bool
+	err            error
+
+	// lastValue is the order value of the last point returned so far, and seen
+	// holds the IDs of the returned points that share it
+	lastValue json.Number
+	seen      []PointID
+}
+
+// ScrollAll creates an iterator over every point matching the request
+// The request limit sets the page size; when ordering by a payload key the iterator
+// resumes from the last order value and excludes the points already returned with it,
+// since ordered scrolls have no next page offset
+// A nil request scrolls every point with the server default page size
+func (c *Client) ScrollAll(collectionName string, request *ScrollRequest) *ScrollIterator {
+	if request == nil {
+		request = &ScrollRequest{}
+	}
+	return &ScrollIterator{
+		client:         c,
+		collectionName: collectionName,
+		request:        *request,
+	}
+}
+
+// Next advances to the next point, fetching the next page when the current one is used up
+// It returns false when there are no more points or an error occurred
+func (it *ScrollIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// Record returns the current point
+func (it *ScrollIterator) Record() Record {
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ScrollIterator) Err() error {
+	return it.err
+}
+
+// fetch loads the next page and prepares the cursor for the one after it
+func (it *ScrollIterator) fetch(ctx context.Context) error {
+	request := it.request
+	if request.OrderBy != nil && it.lastValue != "" {
+		orderBy := *request.OrderBy
+		orderBy.StartFrom = it.lastValue
+		request.OrderBy = &orderBy
+
+		filter := &Filter{MustNot: []Condition{NewHasID(it.seen...)}}
+		if request.Filter != nil {
+			filter.Must = []Condition{NewFilterAsCondition(request.Filter)}
+		}
+		request.Filter = filter
+	}
+
+	response, err := it.client.ScrollPoints(ctx, it.collectionName, &request)
+	if err != nil {
+		return err
+	}
+
+	it.page = response.Result.Points
+	it.index = 0
+
+	if it.request.OrderBy == nil {
+		it.request.Offset = response.Result.NextPageOffset
+		it.done = response.Result.NextPageOffset == nil
+		return nil
+	}
+
+	// Ordered scrolls end with the first page that is not full
+	it.done = len(it.page) == 0 || (request.Limit > 0 && uint64(len(it.page)) < request.Limit)
+	for _, record := range it.page {
+		if record.OrderValue == "" {
+			return fmt.Errorf("point %s has no order value", record.ID)
+		}
+		if record.OrderValue != it.lastValue {
+			it.lastValue = record.OrderValue
+			it.seen = nil
+		}
+		it.seen = append(it.seen, record.ID)
+	}
+	return nil
+}
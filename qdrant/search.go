@@ -0,0 +1,226 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SearchParams represents additional parameters that tune how a search is performed
+type SearchParams struct {
+	HnswEf       *uint64                   `json:"hnsw_ef,omitempty"`
+	Exact        *bool                     `json:"exact,omitempty"`
+	Quantization *QuantizationSearchParams `json:"quantization,omitempty"`
+	IndexedOnly  *bool                     `json:"indexed_only,omitempty"`
+	Acorn        *AcornSearchParams        `json:"acorn,omitempty"`
+}
+
+// QuantizationSearchParams controls how quantized vectors are used during a search
+type QuantizationSearchParams struct {
+	Ignore       *bool    `json:"ignore,omitempty"`
+	Rescore      *bool    `json:"rescore,omitempty"`
+	Oversampling *float64 `json:"oversampling,omitempty"`
+}
+
+// AcornSearchParams controls the ACORN algorithm used for restrictive filters
+type AcornSearchParams struct {
+	Enable         *bool    `json:"enable,omitempty"`
+	MaxSelectivity *float64 `json:"max_selectivity,omitempty"`
+}
+
+// NamedVector represents a search vector, optionally naming the vector field to search in
+// Sparse vectors must always be named
+type NamedVector struct {
+	Name   string
+	Dense  []float32
+	Sparse *SparseVector
+}
+
+// MarshalJSON encodes the vector as a plain array or as a named vector object
+func (v NamedVector) MarshalJSON() ([]byte, error) {
+	if v.Sparse != nil {
+		return json.Marshal(struct {
+			Name   string        `json:"name"`
+			Vector *SparseVector `json:"vector"`
+		}{
+			Name:   v.Name,
+			Vector: v.Sparse,
+		})
+	}
+
+	if v.Name == "" {
+		return json.Marshal(v.Dense)
+	}
+
+	return json.Marshal(struct {
+		Name   string    `json:"name"`
+		Vector []float32 `json:"vector"`
+	}{
+		Name:   v.Name,
+		Vector: v.Dense,
+	})
+}
+
+// validate checks the search vector before it is sent
+func (v *NamedVector) validate() error {
+	if v.Sparse == nil {
+		return nil
+	}
+
+	if v.Name == "" {
+		return fmt.Errorf("sparse search vector must be named")
+	}
+	return v.Sparse.Validate()
+}
+
+// SearchRequest represents the request body for a nearest neighbours search
+type SearchRequest struct {
+	Vector         NamedVector       `json:"vector"`
+	Filter         *Filter           `json:"filter,omitempty"`
+	Params         *SearchParams     `json:"params,omitempty"`
+	Limit          uint64            `json:"limit"`
+	Offset         uint64            `json:"offset,omitempty"`
+	WithPayload    *PayloadSelector  `json:"with_payload,omitempty"`
+	WithVector     *VectorSelector   `json:"with_vector,omitempty"`
+	ScoreThreshold *float32          `json:"score_threshold,omitempty"`
+	ShardKey       *ShardKeySelector `json:"shard_key,omitempty"`
+
+	// Consistency is ignored for searches sent as part of a batch
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks the request before it is sent
+func (r *SearchRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	if err := r.ShardKey.validate(); err != nil {
+		return err
+	}
+	if err := r.Vector.validate(); err != nil {
+		return fmt.Errorf("vector: %w", err)
+	}
+	if err := r.Filter.validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if err := r.WithPayload.validate(); err != nil {
+		return fmt.Errorf("with_payload: %w", err)
+	}
+	return nil
+}
+
+// SearchBatchRequest represents the request body for a batch of searches
+type SearchBatchRequest struct {
+	Searches []SearchRequest `json:"searches"`
+
+	Consistency *ReadConsistency `json:"-"`
+}
+
+// validate checks every search of the batch before it is sent
+func (r *SearchBatchRequest) validate() error {
+	if err := r.Consistency.validate(); err != nil {
+		return err
+	}
+	for i := range r.Searches {
+		if err := r.Searches[i].validate(); err != nil {
+			return fmt.Errorf("search %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// SearchResponse represents the response from a search
+type SearchResponse struct {
+	Usage  *Usage        `json:"usage"`
+	Time   float64       `json:"time"`
+	Status string        `json:"status"`
+	Result []ScoredPoint `json:"result"`
+}
+
+// SearchBatchResponse represents the response from a batch of searches
+type SearchBatchResponse struct {
+	Usage  *Usage          `json:"usage"`
+	Time   float64         `json:"time"`
+	Status string          `json:"status"`
+	Result [][]ScoredPoint `json:"result"`
+}
+
+// Search finds the points closest to the given vector
+func (c *Client) Search(ctx context.Context, collectionName string, request *SearchRequest) (*SearchResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/search", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// SearchBatch runs multiple searches in a single request
+func (c *Client) SearchBatch(ctx context.Context, collectionName string, request *SearchBatchRequest) (*SearchBatchResponse, error) {
+	path := fmt.Sprintf("/collections/%s/points/search/batch", collectionName) + request.Consistency.query()
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response SearchBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,92 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VersionInfo represents the name and version of the server
+type VersionInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+}
+
+// AtLeast reports whether the server version is at least major.minor
+// Versions that cannot be parsed are treated as older than any release
+func (v *VersionInfo) AtLeast(major int, minor int) bool {
+	parts := strings.SplitN(strings.TrimPrefix(v.Version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	if gotMajor != major {
+		return gotMajor > major
+	}
+	return gotMinor >= minor
+}
+
+// GetVersion returns the name and version of the server
+func (c *Client) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	path := "/"
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response VersionInfo
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// serverVersion returns the server version, fetching it until a request succeeds
+// The lock is not held during the request, so concurrent first calls may each fetch it
+func (c *Client) serverVersion(ctx context.Context) (*VersionInfo, error) {
+	c.versionMu.Lock()
+	version := c.version
+	c.versionMu.Unlock()
+
+	if version != nil {
+		return version, nil
+	}
+
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting server version: %w", err)
+	}
+
+	c.versionMu.Lock()
+	c.version = version
+	c.versionMu.Unlock()
+	return version, nil
+}
@@ -0,0 +1,233 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// ShardingMethod selects how points are distributed across the shards of a collection
+// With custom sharding shards are created per shard key and writes must name their shard key
+type ShardingMethod string
+
+const (
+	ShardingMethodAuto   ShardingMethod = "auto"
+	ShardingMethodCustom ShardingMethod = "custom"
+)
+
+// ReplicaState represents the state of a shard replica
+type ReplicaState string
+
+const (
+	ReplicaStateActive              ReplicaState = "Active"
+	ReplicaStateDead                ReplicaState = "Dead"
+	ReplicaStatePartial             ReplicaState = "Partial"
+	ReplicaStateInitializing        ReplicaState = "Initializing"
+	ReplicaStateListener            ReplicaState = "Listener"
+	ReplicaStatePartialSnapshot     ReplicaState = "PartialSnapshot"
+	ReplicaStateRecovery            ReplicaState = "Recovery"
+	ReplicaStateResharding          ReplicaState = "Resharding"
+	ReplicaStateReshardingScaleDown ReplicaState = "ReshardingScaleDown"
+	ReplicaStateActiveRead          ReplicaState = "ActiveRead"
+)
+
+// ShardKey represents a custom shard key, either a keyword or an unsigned integer
+// When Keyword is empty the key is the number Num
+type ShardKey struct {
+	Keyword string
+	Num     uint64
+}
+
+// NewShardKeyKeyword creates a keyword shard key
+func NewShardKeyKeyword(keyword string) ShardKey {
+	return ShardKey{Keyword: keyword}
+}
+
+// NewShardKeyNum creates an integer shard key
+func NewShardKeyNum(num uint64) ShardKey {
+	return ShardKey{Num: num}
+}
+
+// String returns the textual form of the shard key
+func (k ShardKey) String() string {
+	if k.Keyword != "" {
+		return k.Keyword
+	}
+	return strconv.FormatUint(k.Num, 10)
+}
+
+// MarshalJSON encodes the shard key as a JSON string or number
+func (k ShardKey) MarshalJSON() ([]byte, error) {
+	if k.Keyword != "" {
+		return json.Marshal(k.Keyword)
+	}
+	return json.Marshal(k.Num)
+}
+
+// UnmarshalJSON decodes a shard key from a JSON string or number
+func (k *ShardKey) UnmarshalJSON(data []byte) error {
+	*k = ShardKey{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &k.Keyword); err != nil {
+			return err
+		}
+		if k.Keyword == "" {
+			return fmt.Errorf("shard key must not be an empty string")
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &k.Num); err != nil {
+		return fmt.Errorf("shard key must be a string or an unsigned integer: %w", err)
+	}
+	return nil
+}
+
+// ShardKeySelector selects the shard keys an operation is routed to
+// Operations without a selector are routed to every shard
+type ShardKeySelector struct {
+	Keys []ShardKey
+}
+
+// NewShardKeySelector creates a selector for the given shard keys
+func NewShardKeySelector(keys ...ShardKey) *ShardKeySelector {
+	return &ShardKeySelector{Keys: keys}
+}
+
+// validate checks that at least one key is selected
+func (s *ShardKeySelector) validate() error {
+	if s == nil {
+		return nil
+	}
+	if len(s.Keys) == 0 {
+		return fmt.Errorf("shard key selector must contain at least one key")
+	}
+	return nil
+}
+
+// MarshalJSON encodes a single shard key as a scalar and several as an array
+func (s ShardKeySelector) MarshalJSON() ([]byte, error) {
+	if len(s.Keys) == 1 {
+		return json.Marshal(s.Keys[0])
+	}
+	return json.Marshal(s.Keys)
+}
+
+// CreateShardKeyRequest represents the request body for creating the shards of a shard key
+// Unset fields fall back to the collection configuration; Placement lists the peers the
+// shards are placed on, otherwise the server chooses
+type CreateShardKeyRequest struct {
+	ShardKey          ShardKey     `json:"shard_key"`
+	ShardsNumber      *uint32      `json:"shards_number,omitempty"`
+	ReplicationFactor *uint32      `json:"replication_factor,omitempty"`
+	Placement         []uint64     `json:"placement,omitempty"`
+	InitialState      ReplicaState `json:"initial_state,omitempty"`
+}
+
+// validate checks the request before it is sent
+func (r *CreateShardKeyRequest) validate() error {
+	if r.ShardsNumber != nil && *r.ShardsNumber == 0 {
+		return fmt.Errorf("shards number must be positive")
+	}
+	if r.ReplicationFactor != nil && *r.ReplicationFactor == 0 {
+		return fmt.Errorf("replication factor must be positive")
+	}
+	switch r.InitialState {
+	case "", ReplicaStateActive, ReplicaStatePartial:
+	default:
+		return fmt.Errorf("initial state must be %s or %s, got %s", ReplicaStateActive, ReplicaStatePartial, r.InitialState)
+	}
+	return nil
+}
+
+// DeleteShardKeyRequest represents the request body for deleting the shards of a shard key
+type DeleteShardKeyRequest struct {
+	ShardKey ShardKey `json:"shard_key"`
+}
+
+// ShardKeyResponse represents the response from creating or deleting a shard key
+type ShardKeyResponse struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result bool    `json:"result"`
+}
+
+// CreateShardKey creates the shards of a new shard key in a collection with custom sharding
+func (c *Client) CreateShardKey(ctx context.Context, collectionName string, request *CreateShardKeyRequest) (*ShardKeyResponse, error) {
+	path := fmt.Sprintf("/collections/%s/shards", collectionName)
+
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response ShardKeyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteShardKey deletes the shards of a shard key together with all of their points
+func (c *Client) DeleteShardKey(ctx context.Context, collectionName string, shardKey ShardKey) (*ShardKeyResponse, error) {
+	path := fmt.Sprintf("/collections/%s/shards/delete", collectionName)
+
+	bodyBytes, err := json.Marshal(&DeleteShardKeyRequest{ShardKey: shardKey})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, toReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response ShardKeyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &response, nil
+}
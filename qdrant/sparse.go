@@ -0,0 +1,83 @@
+package qdrant
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SparseVector represents a sparse vector as parallel lists of indices and values
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+// NewSparseVectorFromMap creates a sparse vector from an index to value map
+// The resulting indices are sorted in ascending order
+func NewSparseVectorFromMap(m map[uint32]float32) *SparseVector {
+	v := &SparseVector{
+		Indices: make([]uint32, 0, len(m)),
+		Values:  make([]float32, 0, len(m)),
+	}
+	for index := range m {
+		v.Indices = append(v.Indices, index)
+	}
+	sort.Slice(v.Indices, func(i, j int) bool { return v.Indices[i] < v.Indices[j] })
+	for _, index := range v.Indices {
+		v.Values = append(v.Values, m[index])
+	}
+	return v
+}
+
+// Validate checks that indices and values have the same length and that indices are unique
+func (v *SparseVector) Validate() error {
+	if len(v.Indices) != len(v.Values) {
+		return fmt.Errorf("sparse vector has %d indices but %d values", len(v.Indices), len(v.Values))
+	}
+
+	seen := make(map[uint32]struct{}, len(v.Indices))
+	for _, index := range v.Indices {
+		if _, ok := seen[index]; ok {
+			return fmt.Errorf("sparse vector has duplicate index %d", index)
+		}
+		seen[index] = struct{}{}
+	}
+	return nil
+}
+
+// Sort orders the entries of the vector by ascending index
+// Vectors whose indices and values differ in length are left untouched
+func (v *SparseVector) Sort() {
+	if len(v.Indices) != len(v.Values) {
+		return
+	}
+	sort.Sort(sparseVectorSorter{v})
+}
+
+// ToMap returns the vector as an index to value map
+// Entries past the shorter of indices and values are ignored
+func (v *SparseVector) ToMap() map[uint32]float32 {
+	n := min(len(v.Indices), len(v.Values))
+	m := make(map[uint32]float32, n)
+	for i := 0; i < n; i++ {
+		m[v.Indices[i]] = v.Values[i]
+	}
+	return m
+}
+
+// sparseVectorSorter sorts indices and values of a sparse vector together
+type sparseVectorSorter struct {
+	v *SparseVector
+}
+
+func (s sparseVectorSorter) Len() int {
+	return len(s.v.Indices)
+}
+
+func (s sparseVectorSorter) Less(i, j int) bool {
+	return s.v.Indices[i] < s.v.Indices[j]
+}
+
+func (s sparseVectorSorter) Swap(i, j int) {
+	s.v.Indices[i], s.v.Indices[j] = s.v.Indices[j], s.v.Indices[i]
+	s.v.Values[i], s.v.Values[j] = s.v.Values[j], s.v.Values[i]
+}
@@ -0,0 +1,224 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// defaultTransferPollInterval is how often the cluster info is polled while waiting for a transfer
+const defaultTransferPollInterval = time.Second
+
+// defaultTransferSettlePolls is how many polls the destination replica may take to settle
+// once its transfer is gone when unset
+const defaultTransferSettlePolls = 30
+
+// abortTransferTimeout bounds the abort request sent after the caller's deadline has passed
+const abortTransferTimeout = 10 * time.Second
+
+// transferProgressPattern matches the "(done/total)" part of a transfer comment
+var transferProgressPattern = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// ShardReplica represents a replica of a shard placed on a peer
+// PointsCount is only known for shards local to the peer that answered
+type ShardReplica struct {
+	ShardID     uint64
+	ShardKey    *ShardKey
+	PeerID      uint64
+	State       ReplicaState
+	PointsCount *uint64
+}
+
+// Replicas returns every replica of the collection, combining the shards local to the
+// peer that answered with those it knows on remote peers
+func (info *CollectionClusterInfo) Replicas() []ShardReplica {
+	replicas := make([]ShardReplica, 0, len(info.LocalShards)+len(info.RemoteShards))
+	for _, shard := range info.LocalShards {
+		pointsCount := shard.PointsCount
+		replicas = append(replicas, ShardReplica{
+			ShardID:     shard.ShardID,
+			ShardKey:    shard.ShardKey,
+			PeerID:      info.PeerID,
+			State:       ReplicaState(shard.State),
+			PointsCount: &pointsCount,
+		})
+	}
+	for _, shard := range info.RemoteShards {
+		replicas = append(replicas, ShardReplica{
+			ShardID:  shard.ShardID,
+			ShardKey: shard.ShardKey,
+			PeerID:   shard.PeerID,
+			State:    ReplicaState(shard.State),
+		})
+	}
+	return replicas
+}
+
+// replicaState returns the state of a shard replica on a peer, and false when the peer has none
+func (info *CollectionClusterInfo) replicaState(shardID uint64, peerID uint64) (ReplicaState, bool) {
+	for _, replica := range info.Replicas() {
+		if replica.ShardID == shardID && replica.PeerID == peerID {
+			return replica.State, true
+		}
+	}
+	return "", false
+}
+
+// TransferOutcome describes how a shard transfer ended
+type TransferOutcome string
+
+const (
+	TransferFinished TransferOutcome = "finished"
+	TransferFailed   TransferOutcome = "failed"
+	TransferAborted  TransferOutcome = "aborted"
+	TransferStalled  TransferOutcome = "stalled"
+)
+
+// TransferProgress describes the progress of an ongoing shard transfer
+// Done and Total are parsed from the transfer comment and are zero when it has no counts
+type TransferProgress struct {
+	Transfer ShardTransfer
+	Done     uint64
+	Total    uint64
+}
+
+// Fraction returns the completed share of the transfer between 0 and 1, or 0 when unknown
+func (p TransferProgress) Fraction() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
+// newTransferProgress parses the progress out of the comment of a transfer
+func newTransferProgress(transfer ShardTransfer) TransferProgress {
+	progress := TransferProgress{Transfer: transfer}
+	if match := transferProgressPattern.FindStringSubmatch(transfer.Comment); match != nil {
+		progress.Done, _ = strconv.ParseUint(match[1], 10, 64)
+		progress.Total, _ = strconv.ParseUint(match[2], 10, 64)
+	}
+	return progress
+}
+
+// WaitForTransferOptions controls how WaitForTransfer polls and gives up
+type WaitForTransferOptions struct {
+	// PollInterval defaults to one second
+	PollInterval time.Duration
+	// AbortOnDeadline aborts the transfer when the context deadline passes
+	AbortOnDeadline bool
+	// OnProgress is called after every poll that finds the transfer still running
+	OnProgress func(TransferProgress)
+	// SettlePolls is how many polls the destination replica may stay in Partial or
+	// Recovery once the transfer is gone before the transfer counts as stalled, thirty
+	// when unset
+	SettlePolls int
+	// StartPolls is how many polls may find neither the transfer nor its destination
+	// replica before the transfer is first seen, for a transfer that was just requested;
+	// such a transfer counts as aborted on the first poll when unset
+	StartPolls int
+}
+
+// TransferResult describes how a shard transfer ended
+// State is the state of the destination replica when the transfer was last seen gone
+type TransferResult struct {
+	Outcome TransferOutcome
+	State   ReplicaState
+}
+
+// WaitForTransfer blocks until the transfer of a shard between two peers is no longer running
+// The transfer is finished when the destination replica becomes active, failed when it
+// is dead and aborted when it is gone. It is stalled when the destination stays in
+// Partial or Recovery for SettlePolls polls after the transfer is gone. When the
+// context deadline passes and AbortOnDeadline is set the transfer is aborted and an
+// aborted result is returned together with the context error
+func (c *Client) WaitForTransfer(ctx context.Context, collectionName string, shardID uint64, fromPeerID uint64, toPeerID uint64, options *WaitForTransferOptions) (*TransferResult, error) {
+	if options == nil {
+		options = &WaitForTransferOptions{}
+	}
+
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = defaultTransferPollInterval
+	}
+
+	settlePolls := options.SettlePolls
+	if settlePolls <= 0 {
+		settlePolls = defaultTransferSettlePolls
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	seen := false
+	unseen, unsettled := 0, 0
+	for {
+		select {
+		case <-ctx.Done():
+			if options.AbortOnDeadline && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortTransferTimeout)
+				defer cancel()
+
+				if _, err := c.AbortTransfer(abortCtx, collectionName, shardID, fromPeerID, toPeerID); err != nil {
+					return nil, fmt.Errorf("aborting transfer after %w: %w", ctx.Err(), err)
+				}
+				return &TransferResult{Outcome: TransferAborted}, ctx.Err()
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		response, err := c.GetCollectionClusterInfo(ctx, collectionName)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Let the next iteration handle the deadline
+				continue
+			}
+			return nil, err
+		}
+		info := &response.Result
+
+		if transfer, ok := findTransfer(info.ShardTransfers, shardID, fromPeerID, toPeerID); ok {
+			seen = true
+			if options.OnProgress != nil {
+				options.OnProgress(newTransferProgress(transfer))
+			}
+			timer.Reset(interval)
+			continue
+		}
+
+		state, ok := info.replicaState(shardID, toPeerID)
+		switch {
+		case !ok && !seen && unseen < options.StartPolls:
+			// The transfer may not have started yet
+			unseen++
+			timer.Reset(interval)
+			continue
+		case !ok:
+			return &TransferResult{Outcome: TransferAborted}, nil
+		case state == ReplicaStateDead:
+			return &TransferResult{Outcome: TransferFailed, State: state}, nil
+		case state == ReplicaStateActive:
+			return &TransferResult{Outcome: TransferFinished, State: state}, nil
+		}
+
+		// The transfer is gone but the replica has not settled yet
+		unsettled++
+		if unsettled >= settlePolls {
+			return &TransferResult{Outcome: TransferStalled, State: state}, nil
+		}
+		timer.Reset(interval)
+	}
+}
+
+// findTransfer looks up the transfer of a shard between two peers
+func findTransfer(transfers []ShardTransfer, shardID uint64, fromPeerID uint64, toPeerID uint64) (ShardTransfer, bool) {
+	for _, transfer := range transfers {
+		if transfer.ShardID == shardID && transfer.FromPeerID == fromPeerID && transfer.ToPeerID == toPeerID {
+			return transfer, true
+		}
+	}
+	return ShardTransfer{}, false
+}
@@ -0,0 +1,54 @@
+package qdrant_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestTokenizer(t *testing.T) {
+	tokenizer := qdrant.Tokenizer{
+		MinTokenLen: 2,
+		Stopwords:   qdrant.NewStopwords("the", "of"),
+	}
+
+	got := tokenizer.Tokenize("The Lord of the Rings: a 3-part Épopée")
+	want := []string{"lord", "rings", "part", "épopée"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected terms: got %v, want %v", got, want)
+	}
+}
+
+func TestBM25EncoderDocumentAndQuery(t *testing.T) {
+	encoder := qdrant.NewBM25Encoder(4)
+
+	doc := encoder.EncodeDocument("red red red shoes")
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("invalid document vector: %v", err)
+	}
+
+	weights := doc.ToMap()
+	red := weights[qdrant.TermIndex("red")]
+	shoes := weights[qdrant.TermIndex("shoes")]
+
+	if len(weights) != 2 {
+		t.Fatalf("expected 2 terms, got %d", len(weights))
+	}
+
+	if red <= shoes {
+		t.Errorf("expected repeated term to weigh more: red=%f shoes=%f", red, shoes)
+	}
+
+	if red >= 3*shoes {
+		t.Errorf("expected term frequency to saturate: red=%f shoes=%f", red, shoes)
+	}
+
+	query := encoder.EncodeQuery("Red shoes red")
+	if !reflect.DeepEqual(query.ToMap(), map[uint32]float32{
+		qdrant.TermIndex("red"):   1,
+		qdrant.TermIndex("shoes"): 1,
+	}) {
+		t.Errorf("unexpected query vector: %+v", query)
+	}
+}
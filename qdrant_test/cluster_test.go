@@ -0,0 +1,162 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestRemovePeerWithShards(t *testing.T) {
+	var query string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.Method != http.MethodDelete || r.URL.Path != "/cluster/peer/42" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":{"error":"Bad request: Cannot remove peer 42 as there are shards on it"},"time":0}`))
+	})
+
+	_, err := client.RemovePeer(context.Background(), 42, false, 1500*time.Millisecond)
+
+	var shardsErr *qdrant.PeerHasShardsError
+	if !errors.As(err, &shardsErr) {
+		t.Fatalf("expected PeerHasShardsError, got %v", err)
+	}
+
+	if shardsErr.PeerID != 42 {
+		t.Errorf("expected peer 42, got %d", shardsErr.PeerID)
+	}
+
+	var apiErr *qdrant.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected wrapped APIError, got %v", err)
+	}
+
+	if query != "timeout=2" {
+		t.Errorf("unexpected query %q", query)
+	}
+}
+
+func TestRemovePeerForce(t *testing.T) {
+	var query string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+	})
+
+	response, err := client.RemovePeer(context.Background(), 42, true, 0)
+	if err != nil {
+		t.Fatalf("failed to remove peer: %v", err)
+	}
+
+	if !response.Result || query != "force=true" {
+		t.Errorf("unexpected result %v with query %q", response.Result, query)
+	}
+}
+
+func TestClusterSetupOperations(t *testing.T) {
+	var bodies []string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(bodyBytes))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+	})
+
+	ctx := context.Background()
+	toShard := uint64(3)
+	if _, err := client.UpdateCollectionClusterSetup(ctx, "docs", &qdrant.UpdateCollectionClusterSetupRequest{
+		MoveShard: &qdrant.MoveShardRequest{
+			ShardID:    1,
+			ToShardID:  &toShard,
+			FromPeerID: 10,
+			ToPeerID:   10,
+			Method:     qdrant.ShardTransferMethodSnapshot,
+		},
+	}); err != nil {
+		t.Fatalf("failed to move shard: %v", err)
+	}
+
+	if _, err := client.ReplicateShard(ctx, "docs", 1, 10, 20); err != nil {
+		t.Fatalf("failed to replicate shard: %v", err)
+	}
+
+	if _, err := client.ReplicatePoints(ctx, "docs", qdrant.NewShardKeyKeyword("default"), qdrant.NewShardKeyKeyword("tenant_a"),
+		&qdrant.Filter{Must: []qdrant.Condition{qdrant.NewMatch("tenant", "a")}}); err != nil {
+		t.Fatalf("failed to replicate points: %v", err)
+	}
+
+	if _, err := client.StartResharding(ctx, "docs", &qdrant.StartReshardingRequest{Direction: qdrant.ReshardingDirectionUp}); err != nil {
+		t.Fatalf("failed to start resharding: %v", err)
+	}
+
+	if _, err := client.AbortResharding(ctx, "docs"); err != nil {
+		t.Fatalf("failed to abort resharding: %v", err)
+	}
+
+	want := []string{
+		`{"move_shard":{"shard_id":1,"to_shard_id":3,"from_peer_id":10,"to_peer_id":10,"method":"snapshot"}}`,
+		`{"replicate_shard":{"shard_id":1,"from_peer_id":10,"to_peer_id":20}}`,
+		`{"replicate_points":{"from_shard_key":"default","to_shard_key":"tenant_a","filter":{"must":[{"key":"tenant","match":{"value":"a"}}]}}}`,
+		`{"start_resharding":{"direction":"up"}}`,
+		`{"abort_resharding":{}}`,
+	}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected bodies:\n got: %s\nwant: %s", strings.Join(bodies, "\n      "), strings.Join(want, "\n      "))
+	}
+
+	_, err := client.UpdateCollectionClusterSetup(ctx, "docs", &qdrant.UpdateCollectionClusterSetupRequest{
+		DropReplica:     &qdrant.DropReplicaRequest{ShardID: 1, PeerID: 10},
+		AbortResharding: &qdrant.AbortReshardingRequest{},
+	})
+	if err == nil || !strings.Contains(err.Error(), "exactly one operation") {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestShardTransferUnmarshal(t *testing.T) {
+	var transfer qdrant.ShardTransfer
+	data := `{"shard_id":2,"from":10,"to":20,"sync":true,"method":"stream_records","comment":"Transferring records (1200/5000), started 3s ago"}`
+	if err := json.Unmarshal([]byte(data), &transfer); err != nil {
+		t.Fatalf("failed to unmarshal transfer: %v", err)
+	}
+
+	if transfer.FromPeerID != 10 || transfer.ToPeerID != 20 || !transfer.Sync || transfer.Method != qdrant.ShardTransferMethodStreamRecords {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+}
+
+func TestMoveShardWithMethod(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":true}`))
+
+	ctx := context.Background()
+	if _, err := client.MoveShardWithMethod(ctx, "docs", 1, 10, 20, qdrant.ShardTransferMethodWALDelta); err != nil {
+		t.Fatalf("failed to move shard: %v", err)
+	}
+	if _, err := client.MoveShard(ctx, "docs", 1, 10, 20); err != nil {
+		t.Fatalf("failed to move shard: %v", err)
+	}
+	recorder.expectRequests(t,
+		`POST /collections/docs/cluster {"move_shard":{"shard_id":1,"from_peer_id":10,"to_peer_id":20,"method":"wal_delta"}}`,
+		`POST /collections/docs/cluster {"move_shard":{"shard_id":1,"from_peer_id":10,"to_peer_id":20}}`,
+	)
+
+	_, err := client.MoveShardWithMethod(ctx, "docs", 1, 10, 20, "rsync")
+	if err == nil || !strings.Contains(err.Error(), "validating request") {
+		t.Errorf("expected an unknown method to be rejected, got %v", err)
+	}
+}
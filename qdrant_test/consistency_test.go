@@ -0,0 +1,85 @@
+package qdrant_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestReadConsistencyAndShardKey(t *testing.T) {
+	var path, query, body string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		path, query, body = r.URL.Path, r.URL.RawQuery, string(bodyBytes)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/collections/docs/points":
+			w.Write([]byte(`{"status":"ok","time":0,"result":[{"id":7,"payload":{"a":1},"shard_key":"tenant_a"}]}`))
+		case "/collections/docs/points/count":
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"count":12}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+	points, err := client.GetPoints(ctx, "docs", &qdrant.GetPointsRequest{
+		IDs:         []qdrant.PointID{qdrant.NewIDNum(7)},
+		ShardKey:    qdrant.NewShardKeySelector(qdrant.NewShardKeyKeyword("tenant_a")),
+		Consistency: qdrant.NewReadConsistency(qdrant.ReadConsistencyMajority),
+	})
+	if err != nil {
+		t.Fatalf("failed to get points: %v", err)
+	}
+
+	if query != "consistency=majority" {
+		t.Errorf("unexpected query %q", query)
+	}
+
+	if body != `{"ids":[7],"shard_key":"tenant_a"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if len(points.Result) != 1 || points.Result[0].ShardKey == nil || points.Result[0].ShardKey.String() != "tenant_a" {
+		t.Errorf("unexpected result %+v", points.Result)
+	}
+
+	count, err := client.CountPoints(ctx, "docs", &qdrant.CountPointsRequest{
+		ShardKey:    qdrant.NewShardKeySelector(qdrant.NewShardKeyNum(1), qdrant.NewShardKeyNum(2)),
+		Consistency: qdrant.NewReadConsistencyFactor(2),
+	})
+	if err != nil {
+		t.Fatalf("failed to count points: %v", err)
+	}
+
+	if path != "/collections/docs/points/count" || query != "consistency=2" {
+		t.Errorf("unexpected request %s?%s", path, query)
+	}
+
+	if body != `{"shard_key":[1,2]}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if count.Result.Count != 12 {
+		t.Errorf("expected count 12, got %d", count.Result.Count)
+	}
+}
+
+func TestReadConsistencyValidation(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+
+	_, err := client.Search(context.Background(), "docs", &qdrant.SearchRequest{
+		Vector:      qdrant.NamedVector{Dense: []float32{1, 0}},
+		Limit:       1,
+		Consistency: &qdrant.ReadConsistency{Factor: 2, Type: qdrant.ReadConsistencyAll},
+	})
+	if err == nil || !strings.Contains(err.Error(), "validating request") {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package qdrant_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestDiscoverRequestShape(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":[]}`))
+
+	ctx := context.Background()
+	if _, err := client.Discover(ctx, "docs", &qdrant.DiscoverRequest{
+		Target: qdrant.NewVectorInputID(qdrant.NewIDNum(7)),
+		Context: []qdrant.ContextPair{
+			{Positive: *qdrant.NewVectorInputID(qdrant.NewIDUUID("a")), Negative: *qdrant.NewVectorInputDense([]float32{0, 1})},
+		},
+		Limit: 5,
+		Using: "image",
+	}); err != nil {
+		t.Fatalf("failed to discover: %v", err)
+	}
+
+	if _, err := client.ContextSearch(ctx, "docs", &qdrant.DiscoverRequest{
+		Context: []qdrant.ContextPair{
+			{Positive: *qdrant.NewVectorInputID(qdrant.NewIDNum(1)), Negative: *qdrant.NewVectorInputID(qdrant.NewIDNum(2))},
+		},
+		Limit: 3,
+	}); err != nil {
+		t.Fatalf("failed to run context search: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`POST /collections/docs/points/discover {"target":7,"context":[{"positive":"a","negative":[0,1]}],"limit":5,"using":"image"}`,
+		`POST /collections/docs/points/discover {"context":[{"positive":1,"negative":2}],"limit":3}`,
+	)
+}
+
+func TestDiscoverBatchRequestShape(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":[[],[]]}`))
+
+	response, err := client.DiscoverBatch(context.Background(), "docs", &qdrant.DiscoverBatchRequest{
+		Searches: []qdrant.DiscoverRequest{
+			{Target: qdrant.NewVectorInputDense([]float32{1, 0}), Limit: 1},
+			{Target: qdrant.NewVectorInputID(qdrant.NewIDNum(3)), Limit: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to discover: %v", err)
+	}
+
+	if len(response.Result) != 2 {
+		t.Errorf("expected 2 results, got %d", len(response.Result))
+	}
+
+	recorder.expectRequests(t,
+		`POST /collections/docs/points/discover/batch {"searches":[{"target":[1,0],"limit":1},{"target":3,"limit":2}]}`,
+	)
+}
+
+func TestDiscoverValidation(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	pair := qdrant.ContextPair{
+		Positive: *qdrant.NewVectorInputID(qdrant.NewIDNum(1)),
+		Negative: *qdrant.NewVectorInputID(qdrant.NewIDNum(2)),
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		message string
+	}{
+		{
+			name: "context search with target",
+			run: func() error {
+				_, err := client.ContextSearch(ctx, "docs", &qdrant.DiscoverRequest{
+					Target:  qdrant.NewVectorInputID(qdrant.NewIDNum(3)),
+					Context: []qdrant.ContextPair{pair},
+				})
+				return err
+			},
+			message: "does not accept a target",
+		},
+		{
+			name: "nil context search",
+			run: func() error {
+				_, err := client.ContextSearch(ctx, "docs", nil)
+				return err
+			},
+			message: "request is required",
+		},
+		{
+			name: "context search without context",
+			run: func() error {
+				_, err := client.ContextSearch(ctx, "docs", &qdrant.DiscoverRequest{Limit: 1})
+				return err
+			},
+			message: "at least one context pair",
+		},
+		{
+			name: "malformed context vector",
+			run: func() error {
+				_, err := client.Discover(ctx, "docs", &qdrant.DiscoverRequest{
+					Target: qdrant.NewVectorInputID(qdrant.NewIDNum(3)),
+					Context: []qdrant.ContextPair{
+						pair,
+						{
+							Positive: *qdrant.NewVectorInputSparse(&qdrant.SparseVector{Indices: []uint32{1, 2}, Values: []float32{0.5}}),
+							Negative: *qdrant.NewVectorInputID(qdrant.NewIDNum(4)),
+						},
+					},
+				})
+				return err
+			},
+			message: "context pair 1: positive",
+		},
+		{
+			name: "malformed batch target",
+			run: func() error {
+				_, err := client.DiscoverBatch(ctx, "docs", &qdrant.DiscoverBatchRequest{
+					Searches: []qdrant.DiscoverRequest{
+						{Target: qdrant.NewVectorInputMulti([][]float32{{1, 2}, {3}})},
+					},
+				})
+				return err
+			},
+			message: "search 0: target",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package qdrant_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// drainStub emulates a three peer cluster answering from peer 1 with one collection
+// Replicas map "shard:peer" to their state; a pending transfer finishes after two polls
+type drainStub struct {
+	mu           sync.Mutex
+	replicas     map[string]string
+	pending      *qdrant.ShardTransfer
+	pendingPolls int
+	requests     []string
+}
+
+func (s *drainStub) handler(t *testing.T) http.HandlerFunc {
+	return serveCluster([]uint64{1, 2, 3}, []string{"docs"}, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/cluster/peer/2" && r.Method == http.MethodDelete:
+			s.requests = append(s.requests, "remove 2")
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+		case r.URL.Path == "/collections/docs/cluster" && r.Method == http.MethodPost:
+			var request qdrant.UpdateCollectionClusterSetupRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			switch {
+			case request.DropReplica != nil:
+				s.requests = append(s.requests, fmt.Sprintf("drop %d:%d", request.DropReplica.ShardID, request.DropReplica.PeerID))
+				delete(s.replicas, fmt.Sprintf("%d:%d", request.DropReplica.ShardID, request.DropReplica.PeerID))
+			case request.MoveShard != nil:
+				move := request.MoveShard
+				s.requests = append(s.requests, fmt.Sprintf("move %d:%d->%d", move.ShardID, move.FromPeerID, move.ToPeerID))
+				delete(s.replicas, fmt.Sprintf("%d:%d", move.ShardID, move.FromPeerID))
+				s.replicas[fmt.Sprintf("%d:%d", move.ShardID, move.ToPeerID)] = "Active"
+			default:
+				t.Errorf("unexpected cluster operation")
+			}
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+		case r.URL.Path == "/collections/docs/cluster":
+			transfers := ""
+			if s.pending != nil {
+				body, _ := json.Marshal(s.pending)
+				transfers = string(body)
+
+				// The poll after the drain found the transfer and waited once finds it done
+				s.pendingPolls++
+				if s.pendingPolls == 2 {
+					delete(s.replicas, fmt.Sprintf("%d:%d", s.pending.ShardID, s.pending.FromPeerID))
+					s.replicas[fmt.Sprintf("%d:%d", s.pending.ShardID, s.pending.ToPeerID)] = "Active"
+					s.pending = nil
+				}
+			}
+
+			var local, remote []string
+			for key, state := range s.replicas {
+				var shardID, peerID uint64
+				fmt.Sscanf(key, "%d:%d", &shardID, &peerID)
+				if peerID == 1 {
+					local = append(local, fmt.Sprintf(`{"shard_id":%d,"points_count":10,"state":%q}`, shardID, state))
+				} else {
+					remote = append(remote, fmt.Sprintf(`{"shard_id":%d,"peer_id":%d,"state":%q}`, shardID, peerID, state))
+				}
+			}
+			w.Write([]byte(fmt.Sprintf(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":3,"local_shards":[%s],"remote_shards":[%s],"shard_transfers":[%s]}}`,
+				strings.Join(local, ","), strings.Join(remote, ","), transfers)))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestDrainPeerDropsAndMovesReplicas(t *testing.T) {
+	stub := &drainStub{
+		replicas: map[string]string{
+			// Shard 0 has an active copy on peer 1, so the one on peer 2 is dropped
+			"0:1": "Active", "0:2": "Active",
+			// Shard 1 only lives on peer 2 and moves to the less loaded peer 3
+			"1:2": "Active",
+			"2:1": "Active",
+		},
+	}
+	client := newStubClient(t, stub.handler(t))
+
+	var output bytes.Buffer
+	result, err := client.DrainPeer(context.Background(), 2, &qdrant.DrainOptions{
+		RemovePeer:   true,
+		PollInterval: time.Millisecond,
+		Output:       &output,
+	})
+	if err != nil {
+		t.Fatalf("failed to drain peer: %v\n%s", err, output.String())
+	}
+
+	expected := []string{"drop 0:2", "move 1:2->3", "remove 2"}
+	if strings.Join(stub.requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expected requests %v, got %v", expected, stub.requests)
+	}
+
+	if len(result.Dropped) != 1 || len(result.Moved) != 1 || !result.PeerRemoved {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	for key := range stub.replicas {
+		if strings.HasSuffix(key, ":2") {
+			t.Errorf("replica %s is still on the drained peer", key)
+		}
+	}
+
+	if !strings.Contains(output.String(), "drained peer 2: 1 replicas dropped, 1 moved") {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestDrainPeerResumesInFlightTransfer(t *testing.T) {
+	stub := &drainStub{
+		replicas: map[string]string{
+			"0:1": "Active", "0:2": "Active", "0:3": "Partial",
+		},
+		// An interrupted drain left shard 0 moving from peer 2 to peer 3
+		pending: &qdrant.ShardTransfer{ShardID: 0, FromPeerID: 2, ToPeerID: 3, Sync: true, Comment: "Transferring records (100/400), started 1s ago"},
+	}
+	client := newStubClient(t, stub.handler(t))
+
+	var output bytes.Buffer
+	result, err := client.DrainPeer(context.Background(), 2, &qdrant.DrainOptions{
+		PollInterval: time.Millisecond,
+		Output:       &output,
+	})
+	if err != nil {
+		t.Fatalf("failed to drain peer: %v", err)
+	}
+
+	if !strings.Contains(output.String(), `transfer of shard 0 of "docs": 100/400`) {
+		t.Errorf("expected the transfer progress in the output:\n%s", output.String())
+	}
+
+	if len(stub.requests) != 0 {
+		t.Errorf("expected the drain to only wait for the transfer, got %v", stub.requests)
+	}
+	if len(result.Dropped) != 0 || len(result.Moved) != 0 || result.PeerRemoved {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestDrainPeerAlreadyRemoved(t *testing.T) {
+	// A drain that removed peer 2 is run again
+	client := newStubClient(t, serveCluster([]uint64{1, 3}, []string{"docs"}, rejectRequests(t)))
+
+	var output bytes.Buffer
+	result, err := client.DrainPeer(context.Background(), 2, &qdrant.DrainOptions{
+		RemovePeer: true,
+		Output:     &output,
+	})
+	if err != nil {
+		t.Fatalf("expected a removed peer to count as drained, got %v", err)
+	}
+
+	if len(result.Dropped) != 0 || len(result.Moved) != 0 || result.PeerRemoved {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !strings.Contains(output.String(), "peer 2 is not part of the cluster, nothing to drain") {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
@@ -0,0 +1,114 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestHashingEmbedderIsDeterministic(t *testing.T) {
+	embedder := qdrant.NewHashingEmbedder(32)
+
+	ctx := context.Background()
+	first, err := embedder.EmbedDocuments(ctx, []string{"The quick brown fox", "jumps over"})
+	if err != nil {
+		t.Fatalf("failed to embed: %v", err)
+	}
+
+	second, err := embedder.EmbedQueries(ctx, []string{"the QUICK brown fox!", "jumps over"})
+	if err != nil {
+		t.Fatalf("failed to embed: %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("expected identical vectors for texts with the same words")
+	}
+
+	var norm float64
+	for _, value := range first[0].Dense {
+		norm += float64(value) * float64(value)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("expected unit vector, got squared norm %f", norm)
+	}
+}
+
+func TestUpsertTextsAndQueryText(t *testing.T) {
+	embedder := qdrant.NewHashingEmbedder(8)
+	expected, _ := embedder.EmbedDocuments(context.Background(), []string{"red shoes"})
+
+	var requests []map[string]json.RawMessage
+	config := newStubConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		requests = append(requests, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/collections/products/points/query" {
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"points":[]}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok","time":0,"result":{"operation_id":1,"status":"acknowledged"}}`))
+	})
+	config.Embedder = embedder
+	client := newClientFromConfig(t, config)
+
+	ctx := context.Background()
+	upsert, err := client.UpsertTexts(ctx, "products", "text", []qdrant.TextPoint{
+		{ID: qdrant.NewIDNum(1), Text: "red shoes", Payload: map[string]interface{}{"sku": "A1"}},
+	}, qdrant.WriteOptions{})
+	if err != nil {
+		t.Fatalf("failed to upsert texts: %v", err)
+	}
+
+	if upsert.Result.Status != qdrant.UpdateStatusAcknowledged {
+		t.Errorf("expected acknowledged status, got %s", upsert.Result.Status)
+	}
+
+	if _, err := client.QueryText(ctx, "products", "red shoes", &qdrant.QueryRequest{Using: "text", Limit: 3}); err != nil {
+		t.Fatalf("failed to query text: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	var points []struct {
+		Vector map[string][]float32 `json:"vector"`
+	}
+	if err := json.Unmarshal(requests[0]["points"], &points); err != nil {
+		t.Fatalf("failed to decode points: %v", err)
+	}
+
+	if !reflect.DeepEqual(points[0].Vector["text"], expected[0].Dense) {
+		t.Errorf("unexpected upserted vector: %v", points[0].Vector["text"])
+	}
+
+	var query struct {
+		Nearest []float32 `json:"nearest"`
+	}
+	if err := json.Unmarshal(requests[1]["query"], &query); err != nil {
+		t.Fatalf("failed to decode query: %v", err)
+	}
+
+	if !reflect.DeepEqual(query.Nearest, expected[0].Dense) {
+		t.Errorf("unexpected query vector: %v", query.Nearest)
+	}
+}
+
+func TestQueryTextWithoutEmbedder(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+
+	if _, err := client.QueryText(context.Background(), "products", "red shoes", nil); err == nil {
+		t.Error("expected error without an embedder")
+	}
+}
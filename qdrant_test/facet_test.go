@@ -0,0 +1,85 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestFacetValueUnmarshal(t *testing.T) {
+	var values []qdrant.FacetValue
+	if err := json.Unmarshal([]byte(`["red", -42, true, false]`), &values); err != nil {
+		t.Fatalf("failed to unmarshal facet values: %v", err)
+	}
+
+	want := []interface{}{"red", int64(-42), true, false}
+	for i, value := range values {
+		if value.Value() != want[i] {
+			t.Errorf("value %d: expected %v (%T), got %v (%T)", i, want[i], want[i], value.Value(), value.Value())
+		}
+	}
+
+	if values[1].String() != "-42" || values[2].String() != "true" {
+		t.Errorf("unexpected string forms %q and %q", values[1], values[2])
+	}
+
+	bodyBytes, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("failed to marshal facet values: %v", err)
+	}
+	if string(bodyBytes) != `["red",-42,true,false]` {
+		t.Errorf("unexpected round trip: %s", bodyBytes)
+	}
+}
+
+func TestFacetValueUnmarshalRejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{`1.5`, `null`, `{"a":1}`, `[1]`, `tru`} {
+		var value qdrant.FacetValue
+		if err := json.Unmarshal([]byte(input), &value); err == nil {
+			t.Errorf("expected an error for %s, got %v", input, value.Value())
+		}
+	}
+}
+
+func TestFacet(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"hits":[{"value":"red","count":10},{"value":7,"count":3}]}}`))
+
+	exact := true
+	response, err := client.Facet(context.Background(), "docs", &qdrant.FacetRequest{
+		Key:    "color",
+		Filter: &qdrant.Filter{MustNot: []qdrant.Condition{qdrant.NewIsEmpty("color")}},
+		Limit:  5,
+		Exact:  &exact,
+	})
+	if err != nil {
+		t.Fatalf("failed to facet: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`POST /collections/docs/facet {"key":"color","filter":{"must_not":[{"is_empty":{"key":"color"}}]},"limit":5,"exact":true}`,
+	)
+
+	hits := response.Result.Hits
+	if len(hits) != 2 || hits[0].Value.String() != "red" || hits[0].Count != 10 || *hits[1].Value.Integer != 7 {
+		t.Errorf("unexpected hits: %+v", hits)
+	}
+
+	condition, err := json.Marshal(hits[1].Value.Condition("color"))
+	if err != nil {
+		t.Fatalf("failed to marshal condition: %v", err)
+	}
+	if string(condition) != `{"key":"color","match":{"value":7}}` {
+		t.Errorf("unexpected condition: %s", condition)
+	}
+}
+
+func TestFacetRejectsInvalidKey(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+
+	if _, err := client.Facet(context.Background(), "docs", &qdrant.FacetRequest{Key: "a..b"}); err == nil {
+		t.Error("expected an invalid key error")
+	}
+}
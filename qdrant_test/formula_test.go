@@ -0,0 +1,67 @@
+package qdrant_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestFormulaQueryJSON(t *testing.T) {
+	scale := 86400.0
+	target := qdrant.NewExprDatetime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	request := &qdrant.QueryRequest{
+		Prefetch: []qdrant.Prefetch{{
+			Query: qdrant.NewQueryNearest(qdrant.NewVectorInputDense([]float32{0.1, 0.2})),
+			Limit: 50,
+		}},
+		Query: qdrant.NewQueryFormula(qdrant.NewExprSum(
+			qdrant.NewExprScore(),
+			qdrant.NewExprMult(
+				qdrant.NewExprConstant(0.5),
+				qdrant.NewExprCondition(qdrant.NewMatch("tag", "news")),
+			),
+			qdrant.NewExprGaussDecay(qdrant.DecayParams{
+				X:      qdrant.NewExprDatetimeKey("published_at"),
+				Target: &target,
+				Scale:  &scale,
+			}),
+		), map[string]interface{}{"tag": "none"}),
+		Limit: 10,
+	}
+
+	got, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	want := `{"prefetch":[{"query":{"nearest":[0.1,0.2]},"limit":50}],` +
+		`"query":{"formula":{"sum":["$score",{"mult":[0.5,{"key":"tag","match":{"value":"news"}}]},` +
+		`{"gauss_decay":{"x":{"datetime_key":"published_at"},"target":{"datetime":"2025-01-01T00:00:00Z"},"scale":86400}}]},` +
+		`"defaults":{"tag":"none"}},"limit":10}`
+
+	if string(got) != want {
+		t.Errorf("unexpected JSON:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestFormulaValidation(t *testing.T) {
+	midpoint := 1.5
+	expr := qdrant.NewExprSum(
+		qdrant.NewExprScore(),
+		qdrant.NewExprExpDecay(qdrant.DecayParams{
+			X:        qdrant.NewExprVariable("price"),
+			Midpoint: &midpoint,
+		}),
+	)
+
+	if _, err := json.Marshal(qdrant.NewQueryFormula(expr, nil)); err == nil {
+		t.Error("expected error for invalid midpoint")
+	}
+
+	if _, err := json.Marshal(qdrant.NewQueryFormula(qdrant.NewExprMult(), nil)); err == nil {
+		t.Error("expected error for empty mult")
+	}
+}
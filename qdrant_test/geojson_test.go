@@ -0,0 +1,81 @@
+package qdrant_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestGeoJSONPolygonWithHole(t *testing.T) {
+	data := []byte(`{
+		"type": "Feature",
+		"properties": {"zone": "downtown"},
+		"geometry": {
+			"type": "Polygon",
+			"coordinates": [
+				[[13.3, 52.4], [13.5, 52.4], [13.5, 52.6], [13.3, 52.6], [13.3, 52.4]],
+				[[13.38, 52.48], [13.42, 52.48], [13.42, 52.52], [13.38, 52.48]]
+			]
+		}
+	}`)
+
+	filter, err := qdrant.GeoJSONToFilter("location", data)
+	if err != nil {
+		t.Fatalf("failed to convert geojson: %v", err)
+	}
+
+	if len(filter.Must) != 1 || len(filter.Should) != 0 {
+		t.Fatalf("expected a single must condition, got %+v", filter)
+	}
+
+	polygon := filter.Must[0].GeoPolygon
+	if polygon == nil {
+		t.Fatal("expected a polygon condition")
+	}
+
+	if polygon.Exterior.Points[1] != (qdrant.GeoPoint{Lon: 13.5, Lat: 52.4}) {
+		t.Errorf("expected longitude first, got %+v", polygon.Exterior.Points[1])
+	}
+
+	if len(polygon.Interiors) != 1 || len(polygon.Interiors[0].Points) != 4 {
+		t.Errorf("expected one interior ring with 4 points, got %+v", polygon.Interiors)
+	}
+}
+
+func TestGeoJSONMultiPolygonIsOred(t *testing.T) {
+	data := []byte(`{
+		"type": "MultiPolygon",
+		"coordinates": [
+			[[[0, 0], [1, 0], [1, 1], [0, 0]]],
+			[[[10, 10], [11, 10], [11, 11], [10, 10]]]
+		]
+	}`)
+
+	filter, err := qdrant.GeoJSONToFilter("location", data)
+	if err != nil {
+		t.Fatalf("failed to convert geojson: %v", err)
+	}
+
+	if len(filter.Should) != 2 || len(filter.Must) != 0 {
+		t.Fatalf("expected two should conditions, got %+v", filter)
+	}
+
+	got, err := json.Marshal(filter.Should[1])
+	if err != nil {
+		t.Fatalf("failed to marshal condition: %v", err)
+	}
+
+	want := `{"key":"location","geo_polygon":{"exterior":{"points":[{"lon":10,"lat":10},{"lon":11,"lat":10},{"lon":11,"lat":11},{"lon":10,"lat":10}]}}}`
+	if string(got) != want {
+		t.Errorf("unexpected JSON:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestGeoJSONRejectsOpenRing(t *testing.T) {
+	data := []byte(`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}`)
+
+	if _, err := qdrant.GeoJSONToFilter("location", data); err == nil {
+		t.Error("expected error for an open ring")
+	}
+}
@@ -0,0 +1,231 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// defaultHealthShards has a dead replica of shard 0 on peer 2, a single replica of
+// shard 1 and a healthy shard 2
+const defaultHealthShards = `"local_shards":[{"shard_id":0,"points_count":5,"state":"Active"},{"shard_id":1,"points_count":5,"state":"Active"},{"shard_id":2,"points_count":5,"state":"Active"}],` +
+	`"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Dead"},{"shard_id":2,"peer_id":3,"state":"Active"}]`
+
+// healthStub serves a three peer cluster answering from peer 1 with a collection whose
+// replication factor is two and whose shards default to defaultHealthShards
+// Repairs are recorded as "drop shard:peer" and "shard:from->to"
+type healthStub struct {
+	mu         sync.Mutex
+	shards     string
+	replicated []string
+}
+
+func (s *healthStub) handler(t *testing.T) http.HandlerFunc {
+	return serveCluster([]uint64{1, 2, 3}, []string{"docs"}, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/collections/docs":
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"config":{"params":{"replication_factor":2}}}}`))
+		case r.URL.Path == "/collections/docs/cluster" && r.Method == http.MethodPost:
+			var request qdrant.UpdateCollectionClusterSetupRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			switch {
+			case request.DropReplica != nil:
+				s.replicated = append(s.replicated, fmt.Sprintf("drop %d:%d", request.DropReplica.ShardID, request.DropReplica.PeerID))
+			case request.ReplicateShard != nil:
+				replicate := request.ReplicateShard
+				s.replicated = append(s.replicated, fmt.Sprintf("%d:%d->%d", replicate.ShardID, replicate.FromPeerID, replicate.ToPeerID))
+			default:
+				t.Errorf("expected a drop replica or replicate shard operation")
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+		case r.URL.Path == "/collections/docs/cluster":
+			shards := s.shards
+			if shards == "" {
+				shards = defaultHealthShards
+			}
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":3,` + shards + `,"shard_transfers":[]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestHealthMonitorCheckReportsAndRepairs(t *testing.T) {
+	stub := &healthStub{}
+	client := newStubClient(t, stub.handler(t))
+
+	var emitted []qdrant.HealthEvent
+	monitor := qdrant.NewHealthMonitor(client, qdrant.HealthMonitorOptions{
+		Repair:         true,
+		RepairInterval: time.Hour,
+		OnEvent: func(event qdrant.HealthEvent) {
+			emitted = append(emitted, event)
+		},
+	})
+
+	events, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+
+	expected := []string{
+		`shard 0 of "docs" on peer 2 is Dead`,
+		`shard 0 of "docs" has 1 of 2 active replicas`,
+		`dropping dead replica of shard 0 of "docs" on peer 2`,
+		`replicating shard 0 of "docs" from peer 1 to peer 2`,
+		`shard 1 of "docs" has 1 of 2 active replicas`,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), events)
+	}
+	for i, event := range events {
+		if event.String() != expected[i] {
+			t.Errorf("event %d: expected %q, got %q", i, expected[i], event.String())
+		}
+	}
+
+	if len(emitted) != len(events) {
+		t.Errorf("expected every event to reach the callback, got %d of %d", len(emitted), len(events))
+	}
+
+	// The repair interval has not passed, so the second check only reports
+	if _, err := monitor.Check(context.Background()); err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+	if strings.Join(stub.replicated, ", ") != "drop 0:2, 0:1->2" {
+		t.Errorf("expected a single rate limited repair, got %v", stub.replicated)
+	}
+}
+
+func TestHealthMonitorRunStopsWithContext(t *testing.T) {
+	client := newStubClient(t, (&healthStub{}).handler(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	checks := 0
+	monitor := qdrant.NewHealthMonitor(client, qdrant.HealthMonitorOptions{
+		PollInterval: time.Millisecond,
+		OnEvent: func(event qdrant.HealthEvent) {
+			if event.Type != qdrant.HealthEventReplicaUnhealthy {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			// Every check finds the dead replica once
+			checks++
+			if checks == 2 {
+				cancel()
+			}
+		},
+	})
+
+	if err := monitor.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the monitor to stop with the context, got %v", err)
+	}
+}
+
+func TestHealthMonitorRepairsOntoNewPeers(t *testing.T) {
+	tests := []struct {
+		name     string
+		shards   string
+		expected string
+	}{
+		{
+			name: "dead replica on a removed peer",
+			// Peer 4 left the cluster, so its replica is replaced on the least loaded peer
+			shards: `"local_shards":[{"shard_id":0,"points_count":5,"state":"Active"},{"shard_id":1,"points_count":5,"state":"Active"}],` +
+				`"remote_shards":[{"shard_id":0,"peer_id":4,"state":"Dead"},{"shard_id":1,"peer_id":2,"state":"Active"}]`,
+			expected: "0:1->3",
+		},
+		{
+			name: "missing replica",
+			shards: `"local_shards":[{"shard_id":0,"points_count":5,"state":"Active"},{"shard_id":1,"points_count":5,"state":"Active"}],` +
+				`"remote_shards":[{"shard_id":1,"peer_id":3,"state":"Active"}]`,
+			expected: "0:1->2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &healthStub{shards: test.shards}
+			client := newStubClient(t, stub.handler(t))
+
+			monitor := qdrant.NewHealthMonitor(client, qdrant.HealthMonitorOptions{Repair: true})
+			if _, err := monitor.Check(context.Background()); err != nil {
+				t.Fatalf("failed to check health: %v", err)
+			}
+
+			if strings.Join(stub.replicated, ", ") != test.expected {
+				t.Errorf("expected repair %s, got %v", test.expected, stub.replicated)
+			}
+		})
+	}
+}
+
+func TestHealthMonitorShardKeyReplicationFactor(t *testing.T) {
+	// Shards of tenant_a keep a single replica while the collection asks for two
+	stub := &healthStub{
+		shards: `"local_shards":[{"shard_id":0,"shard_key":"tenant_a","points_count":5,"state":"Active"},{"shard_id":1,"shard_key":"tenant_b","points_count":5,"state":"Active"}],` +
+			`"remote_shards":[]`,
+	}
+	client := newStubClient(t, stub.handler(t))
+
+	monitor := qdrant.NewHealthMonitor(client, qdrant.HealthMonitorOptions{
+		ShardKeyReplicationFactors: map[string]map[qdrant.ShardKey]uint32{
+			"docs": {qdrant.NewShardKeyKeyword("tenant_a"): 1},
+		},
+	})
+	events, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+
+	if len(events) != 1 || events[0].ShardID != 1 || events[0].ShardKey == nil || events[0].ShardKey.String() != "tenant_b" {
+		t.Fatalf("expected only the tenant_b shard to be under replicated, got %v", events)
+	}
+	if events[0].ReplicationFactor != 2 {
+		t.Errorf("expected the collection replication factor, got %d", events[0].ReplicationFactor)
+	}
+}
+
+func TestHealthMonitorConcurrentChecksRepairOnce(t *testing.T) {
+	stub := &healthStub{}
+	client := newStubClient(t, stub.handler(t))
+
+	monitor := qdrant.NewHealthMonitor(client, qdrant.HealthMonitorOptions{
+		Repair:         true,
+		RepairInterval: time.Hour,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := monitor.Check(context.Background()); err != nil {
+				t.Errorf("failed to check health: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if strings.Join(stub.replicated, ", ") != "drop 0:2, 0:1->2" {
+		t.Errorf("expected a single repair, got %v", stub.replicated)
+	}
+}
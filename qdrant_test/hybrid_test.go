@@ -0,0 +1,185 @@
+package qdrant_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// hybridStubHandler answers version, search batch and query requests
+// An empty version makes the version request fail
+func hybridStubHandler(t *testing.T, version string, paths *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			if version == "" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"title":"qdrant - vector search engine","version":"` + version + `"}`))
+		case "/collections/docs/points/search/batch":
+			w.Write([]byte(`{"status":"ok","time":0,"result":[` +
+				`[{"id":1,"version":0,"score":0.9},{"id":2,"version":0,"score":0.8},{"id":3,"version":0,"score":0.7}],` +
+				`[{"id":3,"version":0,"score":12.0},{"id":4,"version":0,"score":9.0}]]}`))
+		case "/collections/docs/points/query":
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"points":[{"id":3,"version":0,"score":0.66}]}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newHybridRequest() *qdrant.HybridSearchRequest {
+	return &qdrant.HybridSearchRequest{
+		Dense:       []float32{0.1, 0.2, 0.3},
+		DenseUsing:  "dense",
+		Sparse:      &qdrant.SparseVector{Indices: []uint32{7}, Values: []float32{1}},
+		SparseUsing: "sparse",
+		Limit:       3,
+	}
+}
+
+func TestHybridSearchClientFusion(t *testing.T) {
+	var paths []string
+	client := newStubClient(t, hybridStubHandler(t, "1.9.2", &paths))
+
+	result, err := client.HybridSearch(context.Background(), "docs", newHybridRequest())
+	if err != nil {
+		t.Fatalf("hybrid search failed: %v", err)
+	}
+
+	if result.FusionPath != qdrant.FusionPathClient {
+		t.Errorf("expected client fusion, got %s", result.FusionPath)
+	}
+
+	if len(result.Points) != 3 {
+		t.Fatalf("expected 3 points, got %d", len(result.Points))
+	}
+
+	// Point 3 appears in both lists so it must come first
+	if result.Points[0].ID != qdrant.NewIDNum(3) {
+		t.Errorf("expected point 3 first, got %s", result.Points[0].ID)
+	}
+
+	if paths[len(paths)-1] != "/collections/docs/points/search/batch" {
+		t.Errorf("expected a search batch request, got %v", paths)
+	}
+}
+
+func TestHybridSearchServerFusion(t *testing.T) {
+	var paths []string
+	client := newStubClient(t, hybridStubHandler(t, "1.12.0", &paths))
+
+	request := newHybridRequest()
+	request.Fusion = qdrant.FusionDBSF
+
+	result, err := client.HybridSearch(context.Background(), "docs", request)
+	if err != nil {
+		t.Fatalf("hybrid search failed: %v", err)
+	}
+
+	if result.FusionPath != qdrant.FusionPathServer {
+		t.Errorf("expected server fusion, got %s", result.FusionPath)
+	}
+
+	if result.Fusion != qdrant.FusionDBSF {
+		t.Errorf("expected dbsf fusion, got %s", result.Fusion)
+	}
+
+	// The server version is cached, so a second search only sends the query
+	if _, err := client.HybridSearch(context.Background(), "docs", request); err != nil {
+		t.Fatalf("hybrid search failed: %v", err)
+	}
+
+	want := []string{"/", "/collections/docs/points/query", "/collections/docs/points/query"}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected requests: %v", paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("unexpected requests: %v", paths)
+			break
+		}
+	}
+}
+
+func TestFuseRRFWeights(t *testing.T) {
+	dense := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(1)}, {ID: qdrant.NewIDNum(2)}}
+	sparse := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(2)}, {ID: qdrant.NewIDNum(1)}}
+
+	fused := qdrant.FuseRRF([][]qdrant.ScoredPoint{dense, sparse}, []float64{1, 3})
+	if fused[0].ID != qdrant.NewIDNum(2) {
+		t.Errorf("expected the sparse favourite first, got %s", fused[0].ID)
+	}
+}
+
+func TestHybridSearchFallsBackWhenVersionUnavailable(t *testing.T) {
+	var paths []string
+	client := newStubClient(t, hybridStubHandler(t, "", &paths))
+
+	request := newHybridRequest()
+	zero := 0.0
+	request.DenseWeight = &zero
+
+	result, err := client.HybridSearch(context.Background(), "docs", request)
+	if err != nil {
+		t.Fatalf("hybrid search failed: %v", err)
+	}
+
+	if result.FusionPath != qdrant.FusionPathClient {
+		t.Errorf("expected client fusion, got %s", result.FusionPath)
+	}
+
+	// The dense list is switched off, so only the sparse results remain
+	if len(result.Points) != 2 || result.Points[0].ID != qdrant.NewIDNum(3) || result.Points[1].ID != qdrant.NewIDNum(4) {
+		t.Errorf("expected only the sparse results, got %+v", result.Points)
+	}
+}
+
+func TestFuseRRFKnownRanks(t *testing.T) {
+	first := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(1)}, {ID: qdrant.NewIDNum(2)}, {ID: qdrant.NewIDNum(3)}}
+	second := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(3)}, {ID: qdrant.NewIDNum(4)}}
+
+	// Ranks count from zero with k = 2, as on the server
+	want := map[uint64]float64{
+		1: 1.0 / 2,
+		2: 1.0 / 3,
+		3: 1.0/4 + 1.0/2,
+		4: 1.0 / 3,
+	}
+
+	fused := qdrant.FuseRRF([][]qdrant.ScoredPoint{first, second}, nil)
+	if len(fused) != len(want) {
+		t.Fatalf("expected %d points, got %d", len(want), len(fused))
+	}
+	for _, point := range fused {
+		if math.Abs(float64(point.Score)-want[point.ID.Num]) > 1e-6 {
+			t.Errorf("point %s: expected score %f, got %f", point.ID, want[point.ID.Num], point.Score)
+		}
+	}
+	if fused[0].ID != qdrant.NewIDNum(3) || fused[1].ID != qdrant.NewIDNum(1) {
+		t.Errorf("unexpected order: %s, %s", fused[0].ID, fused[1].ID)
+	}
+}
+
+func TestFuseZeroWeightSwitchesListOff(t *testing.T) {
+	first := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(1), Score: 0.9}, {ID: qdrant.NewIDNum(2), Score: 0.1}}
+	second := []qdrant.ScoredPoint{{ID: qdrant.NewIDNum(2), Score: 5}}
+
+	for name, fuse := range map[string]func([][]qdrant.ScoredPoint, []float64) []qdrant.ScoredPoint{
+		"rrf":  qdrant.FuseRRF,
+		"dbsf": qdrant.FuseDBSF,
+	} {
+		fused := fuse([][]qdrant.ScoredPoint{first, second}, []float64{0, 1})
+		if len(fused) != 1 || fused[0].ID != qdrant.NewIDNum(2) {
+			t.Errorf("%s: expected only point 2, got %+v", name, fused)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// echoInferenceHandler returns the received request body as the payload of a single point
+func echoInferenceHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+
+		response := map[string]interface{}{
+			"usage": map[string]interface{}{
+				"inference": map[string]interface{}{
+					"models": map[string]interface{}{
+						"sentence-transformers/all-minilm-l6-v2": map[string]interface{}{"tokens": 7},
+					},
+				},
+			},
+			"time":   0.001,
+			"status": "ok",
+			"result": map[string]interface{}{
+				"points": []interface{}{
+					map[string]interface{}{
+						"id":      1,
+						"version": 0,
+						"score":   0.5,
+						"payload": map[string]interface{}{"request": json.RawMessage(body)},
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func TestQueryWithDocument(t *testing.T) {
+	client := newStubClient(t, echoInferenceHandler(t))
+
+	ctx := context.Background()
+	resp, err := client.Query(ctx, "test_collection", &qdrant.QueryRequest{
+		Query: qdrant.NewQueryNearest(qdrant.NewVectorInputDocument(&qdrant.Document{
+			Text:  "where is the nearest bakery",
+			Model: "sentence-transformers/all-minilm-l6-v2",
+		})),
+		Limit:       5,
+		WithPayload: qdrant.NewWithPayload(true),
+	})
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	if len(resp.Result.Points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(resp.Result.Points))
+	}
+
+	echoed, _ := json.Marshal(resp.Result.Points[0].Payload["request"])
+	want := `{"limit":5,"query":{"nearest":{"model":"sentence-transformers/all-minilm-l6-v2","text":"where is the nearest bakery"}},"with_payload":true}`
+	if string(echoed) != want {
+		t.Errorf("unexpected request:\n got: %s\nwant: %s", echoed, want)
+	}
+
+	if resp.Usage == nil {
+		t.Fatal("expected usage to be set")
+	}
+
+	usage, ok := resp.Usage.Inference.Models["sentence-transformers/all-minilm-l6-v2"]
+	if !ok {
+		t.Fatal("expected usage for the model")
+	}
+
+	if usage.Tokens != 7 {
+		t.Errorf("expected 7 tokens, got %d", usage.Tokens)
+	}
+}
+
+func TestQueryRejectsDocumentWithoutModel(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+
+	ctx := context.Background()
+	_, err := client.Query(ctx, "test_collection", &qdrant.QueryRequest{
+		Query: qdrant.NewQueryNearest(qdrant.NewVectorInputDocument(&qdrant.Document{
+			Text: "where is the nearest bakery",
+		})),
+		Limit: 5,
+	})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+}
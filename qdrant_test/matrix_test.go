@@ -0,0 +1,109 @@
+package qdrant_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestSearchMatrixOffsets(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{`+
+		`"offsets_row":[0,0,1,2],"offsets_col":[1,2,0,1],"scores":[0.9,0.4,0.9,0.7],"ids":[10,"b",12]}}`))
+
+	response, err := client.SearchMatrixOffsets(context.Background(), "docs", &qdrant.SearchMatrixRequest{
+		Filter: &qdrant.Filter{Must: []qdrant.Condition{qdrant.NewMatch("lang", "en")}},
+		Sample: 3,
+		Limit:  2,
+		Using:  "image",
+	})
+	if err != nil {
+		t.Fatalf("failed to get matrix: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`POST /collections/docs/points/search/matrix/offsets {"filter":{"must":[{"key":"lang","match":{"value":"en"}}]},"sample":3,"limit":2,"using":"image"}`,
+	)
+
+	matrix := &response.Result
+	if matrix.Dim() != 3 || matrix.Len() != 4 {
+		t.Fatalf("unexpected matrix size %dx%d with %d entries", matrix.Dim(), matrix.Dim(), matrix.Len())
+	}
+
+	if entry := matrix.Entry(3); entry != (qdrant.MatrixEntry{Row: 2, Col: 1, Score: 0.7}) {
+		t.Errorf("unexpected entry 3: %+v", entry)
+	}
+
+	rows := matrix.Rows()
+	if len(rows) != 3 || len(rows[0]) != 2 || len(rows[1]) != 1 || len(rows[2]) != 1 {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if rows[0][1] != (qdrant.MatrixEntry{Row: 0, Col: 2, Score: 0.4}) {
+		t.Errorf("unexpected second entry of row 0: %+v", rows[0][1])
+	}
+
+	want := [][]float32{
+		{-1, 0.9, 0.4},
+		{0.9, -1, -1},
+		{-1, 0.7, -1},
+	}
+	if dense := matrix.Dense(-1); !reflect.DeepEqual(dense, want) {
+		t.Errorf("unexpected dense matrix: %v", dense)
+	}
+
+	if matrix.IDs[1] != qdrant.NewIDUUID("b") {
+		t.Errorf("unexpected ids: %v", matrix.IDs)
+	}
+}
+
+func TestSearchMatrixOffsetsRejectsInconsistentResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+	}{
+		{
+			name:   "mismatched lengths",
+			result: `{"offsets_row":[0,1],"offsets_col":[1],"scores":[0.5,0.5],"ids":[1,2]}`,
+		},
+		{
+			name:   "row out of range",
+			result: `{"offsets_row":[2],"offsets_col":[0],"scores":[0.5],"ids":[1,2]}`,
+		},
+		{
+			name:   "column out of range",
+			result: `{"offsets_row":[0],"offsets_col":[5],"scores":[0.5],"ids":[1,2]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &requestRecorder{}
+			client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":`+tt.result+`}`))
+
+			_, err := client.SearchMatrixOffsets(context.Background(), "docs", &qdrant.SearchMatrixRequest{Sample: 2})
+			if err == nil || !strings.Contains(err.Error(), "decoding response") {
+				t.Errorf("expected a decoding error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSearchMatrixPairs(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"pairs":[{"a":1,"b":"x","score":0.5}]}}`))
+
+	response, err := client.SearchMatrixPairs(context.Background(), "docs", &qdrant.SearchMatrixRequest{Sample: 10, Limit: 1})
+	if err != nil {
+		t.Fatalf("failed to get matrix: %v", err)
+	}
+
+	recorder.expectRequests(t, `POST /collections/docs/points/search/matrix/pairs {"sample":10,"limit":1}`)
+
+	want := []qdrant.SearchMatrixPair{{A: qdrant.NewIDNum(1), B: qdrant.NewIDUUID("x"), Score: 0.5}}
+	if !reflect.DeepEqual(response.Result.Pairs, want) {
+		t.Errorf("unexpected pairs: %+v", response.Result.Pairs)
+	}
+}
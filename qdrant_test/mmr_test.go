@@ -0,0 +1,136 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func mmrCandidates() []qdrant.ScoredPoint {
+	return []qdrant.ScoredPoint{
+		{ID: qdrant.NewIDNum(1), Score: 1.0, Vector: []float32{1, 0}},
+		{ID: qdrant.NewIDNum(2), Score: 0.99, Vector: []float32{0.99, 0.01}},
+		{ID: qdrant.NewIDNum(3), Score: 0.7, Vector: []float32{0.7, 0.7}},
+	}
+}
+
+func TestRerankMMR(t *testing.T) {
+	query := []float32{1, 0}
+
+	diverse, err := qdrant.RerankMMR(query, mmrCandidates(), "", 0.3, 2)
+	if err != nil {
+		t.Fatalf("rerank failed: %v", err)
+	}
+
+	if diverse[0].ID != qdrant.NewIDNum(1) || diverse[1].ID != qdrant.NewIDNum(3) {
+		t.Errorf("expected points 1 and 3, got %s and %s", diverse[0].ID, diverse[1].ID)
+	}
+
+	relevant, err := qdrant.RerankMMR(query, mmrCandidates(), "", 1, 2)
+	if err != nil {
+		t.Fatalf("rerank failed: %v", err)
+	}
+
+	if relevant[0].ID != qdrant.NewIDNum(1) || relevant[1].ID != qdrant.NewIDNum(2) {
+		t.Errorf("expected points 1 and 2, got %s and %s", relevant[0].ID, relevant[1].ID)
+	}
+}
+
+func TestSearchMMRClientFallback(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"title":"qdrant - vector search engine","version":"1.14.1"}`))
+		case "/collections/docs/points/search":
+			var body struct {
+				Limit      uint64   `json:"limit"`
+				WithVector []string `json:"with_vector"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			if body.Limit != 10 || len(body.WithVector) != 1 || body.WithVector[0] != "text" {
+				t.Errorf("unexpected candidates request: %+v", body)
+			}
+
+			w.Write([]byte(`{"status":"ok","time":0,"result":[` +
+				`{"id":1,"version":0,"score":1.0,"vector":{"text":[1,0]}},` +
+				`{"id":2,"version":0,"score":0.99,"vector":{"text":[0.99,0.01]}},` +
+				`{"id":3,"version":0,"score":0.7,"vector":{"text":[0.7,0.7]}}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	lambda := 0.3
+	result, err := client.SearchMMR(context.Background(), "docs", &qdrant.MMRRequest{
+		Vector: []float32{1, 0},
+		Using:  "text",
+		Limit:  2,
+		Lambda: &lambda,
+	})
+	if err != nil {
+		t.Fatalf("mmr search failed: %v", err)
+	}
+
+	if result.ServerSide {
+		t.Error("expected client-side reranking")
+	}
+
+	if len(result.Points) != 2 || result.Points[1].ID != qdrant.NewIDNum(3) {
+		t.Errorf("unexpected points: %+v", result.Points)
+	}
+}
+
+func TestSearchMMRDefaultsLambda(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"title":"qdrant - vector search engine","version":"1.15.0"}`))
+			return
+		}
+		recorder.handler(`{"status":"ok","time":0,"result":{"points":[]}}`)(w, r)
+	})
+
+	result, err := client.SearchMMR(context.Background(), "docs", &qdrant.MMRRequest{
+		Vector: []float32{1, 0},
+		Limit:  2,
+	})
+	if err != nil {
+		t.Fatalf("mmr search failed: %v", err)
+	}
+
+	if !result.ServerSide {
+		t.Error("expected server-side diversification")
+	}
+
+	// An unset lambda balances relevance and diversity instead of ignoring relevance
+	recorder.expectRequests(t,
+		`POST /collections/docs/points/query {"query":{"nearest":[1,0],"mmr":{"diversity":0.5,"candidates_limit":10}},"limit":2}`,
+	)
+}
+
+func TestRerankMMRRejectsInvalidInput(t *testing.T) {
+	query := []float32{1, 0}
+
+	if _, err := qdrant.RerankMMR(query, mmrCandidates(), "", 0.5, 0); err == nil || !strings.Contains(err.Error(), "k must be positive") {
+		t.Errorf("expected a zero k to be rejected, got %v", err)
+	}
+	if _, err := qdrant.RerankMMR(query, mmrCandidates(), "", 0.5, -1); err == nil || !strings.Contains(err.Error(), "k must be positive") {
+		t.Errorf("expected a negative k to be rejected, got %v", err)
+	}
+
+	nan := float32(math.NaN())
+	if _, err := qdrant.RerankMMR([]float32{nan, 0}, mmrCandidates(), "", 0.5, 2); err == nil || !strings.Contains(err.Error(), "NaN") {
+		t.Errorf("expected a NaN query to be rejected, got %v", err)
+	}
+}
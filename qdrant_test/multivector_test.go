@@ -0,0 +1,148 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestCreateMultivectorCollection(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":true}`))
+
+	if _, err := client.CreateCollection(context.Background(), "docs", &qdrant.CreateCollectionRequest{
+		Vectors: &qdrant.VectorsConfig{
+			Named: map[string]qdrant.VectorParams{
+				"colbert": {
+					Size:              128,
+					Distance:          qdrant.DistanceCosine,
+					MultivectorConfig: &qdrant.MultiVectorConfig{Comparator: qdrant.MultiVectorComparatorMaxSim},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create collection: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`PUT /collections/docs {"vectors":{"colbert":{"size":128,"distance":"Cosine","multivector_config":{"comparator":"max_sim"}}}}`,
+	)
+
+	var config qdrant.VectorsConfig
+	if err := json.Unmarshal([]byte(`{"size":4,"distance":"Dot","multivector_config":{"comparator":"max_sim"}}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal vectors config: %v", err)
+	}
+	if config.Params == nil || config.Params.MultivectorConfig == nil || config.Params.MultivectorConfig.Comparator != qdrant.MultiVectorComparatorMaxSim {
+		t.Errorf("unexpected vectors config: %+v", config)
+	}
+}
+
+func TestUpsertVectorShapes(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"operation_id":3,"status":"completed"}}`))
+
+	response, err := client.UpsertPoints(context.Background(), "docs", &qdrant.UpsertPointsRequest{
+		Points: []qdrant.PointStruct{
+			{ID: qdrant.NewIDNum(1), Vector: qdrant.NewVectorsUnnamed(qdrant.NewVectorDense([]float32{0.1, 0.2}))},
+			{ID: qdrant.NewIDNum(2), Vector: qdrant.NewVectorsUnnamed(qdrant.NewVectorMulti([][]float32{{1, 0}, {0, 1}, {1, 1}}))},
+			{
+				ID: qdrant.NewIDUUID("c"),
+				Vector: qdrant.NewVectorsNamed(map[string]*qdrant.Vector{
+					"colbert": qdrant.NewVectorMulti([][]float32{{1, 2, 3}}),
+					"dense":   qdrant.NewVectorDense([]float32{0.5}),
+					"text":    qdrant.NewVectorSparse(&qdrant.SparseVector{Indices: []uint32{3, 9}, Values: []float32{0.3, 0.9}}),
+				}),
+				Payload: map[string]interface{}{"title": "c"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to upsert points: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`PUT /collections/docs/points {"points":[`+
+			`{"id":1,"vector":[0.1,0.2]},`+
+			`{"id":2,"vector":[[1,0],[0,1],[1,1]]},`+
+			`{"id":"c","vector":{"colbert":[[1,2,3]],"dense":[0.5],"text":{"indices":[3,9],"values":[0.3,0.9]}},"payload":{"title":"c"}}]}`,
+	)
+
+	if response.Result.Status != qdrant.UpdateStatusCompleted || response.Result.OperationID == nil || *response.Result.OperationID != 3 {
+		t.Errorf("unexpected update result: %+v", response.Result)
+	}
+}
+
+func TestQueryMultivector(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"points":[]}}`))
+
+	if _, err := client.Query(context.Background(), "docs", &qdrant.QueryRequest{
+		Query: qdrant.NewQueryNearest(qdrant.NewVectorInputMulti([][]float32{{1, 0}, {0, 1}})),
+		Using: "colbert",
+		Limit: 3,
+	}); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	recorder.expectRequests(t, `POST /collections/docs/points/query {"query":{"nearest":[[1,0],[0,1]]},"using":"colbert","limit":3}`)
+}
+
+func TestMultivectorDimensionsAreValidated(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		vectors qdrant.Vectors
+		message string
+	}{
+		{
+			name:    "ragged sub-vectors",
+			vectors: qdrant.NewVectorsUnnamed(qdrant.NewVectorMulti([][]float32{{1, 2}, {3, 4}, {5}})),
+			message: "sub-vector 2 has dimension 1, expected 2",
+		},
+		{
+			name:    "longer sub-vector",
+			vectors: qdrant.NewVectorsUnnamed(qdrant.NewVectorMulti([][]float32{{1, 2}, {3, 4, 5}})),
+			message: "sub-vector 1 has dimension 3, expected 2",
+		},
+		{
+			name:    "empty sub-vector",
+			vectors: qdrant.NewVectorsUnnamed(qdrant.NewVectorMulti([][]float32{{}, {}})),
+			message: "must not contain empty vectors",
+		},
+		{
+			name:    "empty multivector",
+			vectors: qdrant.NewVectorsUnnamed(qdrant.NewVectorMulti([][]float32{})),
+			message: "at least one vector",
+		},
+		{
+			name: "ragged named multivector",
+			vectors: qdrant.NewVectorsNamed(map[string]*qdrant.Vector{
+				"colbert": qdrant.NewVectorMulti([][]float32{{1}, {2, 3}}),
+			}),
+			message: `vector "colbert": multivector sub-vector 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpsertPoints(ctx, "docs", &qdrant.UpsertPointsRequest{
+				Points: []qdrant.PointStruct{{ID: qdrant.NewIDNum(1), Vector: tt.vectors}},
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+
+	if _, err := client.Query(ctx, "docs", &qdrant.QueryRequest{
+		Query: qdrant.NewQueryNearest(qdrant.NewVectorInputMulti([][]float32{{1, 2}, {3}})),
+		Limit: 1,
+	}); err == nil || !strings.Contains(err.Error(), "sub-vector 1 has dimension 1, expected 2") {
+		t.Errorf("expected a ragged query multivector to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestNestedConditionJSON(t *testing.T) {
+	zero := 0.0
+	filter := qdrant.Filter{
+		Must: []qdrant.Condition{
+			qdrant.NewNested("variants", &qdrant.Filter{
+				Must: []qdrant.Condition{
+					qdrant.NewMatch("color", "red"),
+					qdrant.NewRange("stock", qdrant.Range{Gt: &zero}),
+				},
+			}),
+		},
+	}
+
+	got, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("failed to marshal filter: %v", err)
+	}
+
+	want := `{"must":[{"nested":{"key":"variants","filter":{"must":[{"key":"color","match":{"value":"red"}},{"key":"stock","range":{"gt":0}}]}}}]}`
+	if string(got) != want {
+		t.Errorf("unexpected JSON:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestKeyPathValidation(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":[]}`))
+
+	ctx := context.Background()
+
+	valid := []string{"items[].sku", "country.cities[].population", `meta."file.name"`, "matrix[0][]", "a"}
+	for _, key := range valid {
+		_, err := client.Search(ctx, "products", &qdrant.SearchRequest{
+			Vector: qdrant.NamedVector{Dense: []float32{1, 0}},
+			Filter: &qdrant.Filter{Must: []qdrant.Condition{qdrant.NewMatch(key, "x")}},
+			Limit:  1,
+		})
+		if err != nil {
+			t.Errorf("expected key %q to pass validation, got %v", key, err)
+		}
+	}
+
+	invalid := []string{"items[.sku", "items..sku", ".sku", "items.", "items[x]", "[]", `meta."file`, "items]"}
+	for _, key := range invalid {
+		_, err := client.Search(ctx, "products", &qdrant.SearchRequest{
+			Vector:      qdrant.NamedVector{Dense: []float32{1, 0}},
+			Limit:       1,
+			WithPayload: qdrant.NewWithPayloadInclude(key),
+		})
+		if err == nil || !strings.Contains(err.Error(), "validating request") {
+			t.Errorf("expected key %q to fail validation, got %v", key, err)
+		}
+	}
+
+	if requests := recorder.all(); len(requests) != len(valid) {
+		t.Errorf("expected only the valid keys to be sent, got %d requests", len(requests))
+	}
+}
+
+func TestNestedRejectsHasID(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	hasID := qdrant.NewHasID(qdrant.NewIDNum(1))
+	filters := []*qdrant.Filter{
+		{Must: []qdrant.Condition{hasID}},
+		{MinShould: &qdrant.MinShould{Conditions: []qdrant.Condition{hasID}, MinCount: 1}},
+		{Should: []qdrant.Condition{
+			qdrant.NewMatch("color", "red"),
+			qdrant.NewFilterAsCondition(&qdrant.Filter{MustNot: []qdrant.Condition{hasID}}),
+		}},
+		{Must: []qdrant.Condition{
+			qdrant.NewNested("sizes", &qdrant.Filter{Must: []qdrant.Condition{hasID}}),
+		}},
+	}
+
+	for i, filter := range filters {
+		_, err := client.Search(ctx, "products", &qdrant.SearchRequest{
+			Vector: qdrant.NamedVector{Dense: []float32{1, 0}},
+			Filter: &qdrant.Filter{Must: []qdrant.Condition{qdrant.NewNested("variants", filter)}},
+			Limit:  1,
+		})
+		if err == nil || !strings.Contains(err.Error(), "has_id") {
+			t.Errorf("filter %d: expected has_id error, got %v", i, err)
+		}
+	}
+}
+
+func TestNestedRejectsMissingFilter(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+
+	_, err := client.Search(context.Background(), "products", &qdrant.SearchRequest{
+		Vector: qdrant.NamedVector{Dense: []float32{1, 0}},
+		Filter: &qdrant.Filter{Must: []qdrant.Condition{qdrant.NewNested("variants", nil)}},
+		Limit:  1,
+	})
+	if err == nil || !strings.Contains(err.Error(), "nested filter must have at least one condition") {
+		t.Errorf("expected a missing filter error, got %v", err)
+	}
+}
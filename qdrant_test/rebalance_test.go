@@ -0,0 +1,210 @@
+package qdrant_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// rebalanceStub emulates a three peer cluster answering from peer 1 with one collection
+// whose shard moves complete immediately
+type rebalanceStub struct {
+	mu        sync.Mutex
+	placement map[uint64]uint64
+	points    map[uint64]uint64
+	moves     []string
+}
+
+func (s *rebalanceStub) handler(t *testing.T) http.HandlerFunc {
+	return serveCluster([]uint64{1, 2, 3}, []string{"docs"}, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/collections/docs":
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"points_count":1200}}`))
+		case r.URL.Path == "/collections/docs/cluster" && r.Method == http.MethodPost:
+			var request qdrant.UpdateCollectionClusterSetupRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			move := request.MoveShard
+			s.moves = append(s.moves, fmt.Sprintf("%d:%d->%d", move.ShardID, move.FromPeerID, move.ToPeerID))
+			s.placement[move.ShardID] = move.ToPeerID
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+		case r.URL.Path == "/collections/docs/cluster":
+			var local, remote []string
+			for shardID, peerID := range s.placement {
+				if peerID == 1 {
+					local = append(local, fmt.Sprintf(`{"shard_id":%d,"points_count":%d,"state":"Active"}`, shardID, s.points[shardID]))
+				} else {
+					remote = append(remote, fmt.Sprintf(`{"shard_id":%d,"peer_id":%d,"state":"Active"}`, shardID, peerID))
+				}
+			}
+			w.Write([]byte(fmt.Sprintf(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":%d,"local_shards":[%s],"remote_shards":[%s],"shard_transfers":[]}}`,
+				len(s.placement), strings.Join(local, ","), strings.Join(remote, ","))))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestRebalancerEvensReplicaCounts(t *testing.T) {
+	stub := &rebalanceStub{
+		placement: map[uint64]uint64{0: 1, 1: 1, 2: 1, 3: 1, 4: 2, 5: 2},
+	}
+	client := newStubClient(t, stub.handler(t))
+
+	var output bytes.Buffer
+	rebalancer := qdrant.NewRebalancer(client, qdrant.RebalanceOptions{
+		Concurrency:  2,
+		PollInterval: time.Millisecond,
+		Output:       &output,
+	})
+
+	plan, err := rebalancer.Run(context.Background())
+	if err != nil {
+		t.Fatalf("failed to rebalance: %v\n%s", err, output.String())
+	}
+
+	if len(plan.Moves) != 2 {
+		t.Fatalf("expected 2 moves, got %v", plan.Moves)
+	}
+
+	for _, peerID := range []uint64{1, 2, 3} {
+		if plan.After[peerID] != 2 {
+			t.Errorf("expected peer %d to end with 2 replicas, got %d", peerID, plan.After[peerID])
+		}
+	}
+
+	counts := make(map[uint64]int)
+	for _, peerID := range stub.placement {
+		counts[peerID]++
+	}
+	if counts[1] != 2 || counts[2] != 2 || counts[3] != 2 {
+		t.Errorf("expected executed moves to even out placement, got %v after %v", counts, stub.moves)
+	}
+
+	if !strings.Contains(output.String(), "rebalance plan: 2 moves") || strings.Count(output.String(), "finished:") != 2 {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestRebalancerDryRunBalancesPoints(t *testing.T) {
+	// Shard 0 is large; shards on other peers fall back to the 1200 / 4 average
+	stub := &rebalanceStub{
+		placement: map[uint64]uint64{0: 1, 1: 1, 2: 2, 3: 3},
+		points:    map[uint64]uint64{0: 900, 1: 100},
+	}
+	client := newStubClient(t, stub.handler(t))
+
+	var output bytes.Buffer
+	plan, err := qdrant.NewRebalancer(client, qdrant.RebalanceOptions{
+		BalancePoints: true,
+		DryRun:        true,
+		Output:        &output,
+	}).Run(context.Background())
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	if len(stub.moves) != 0 {
+		t.Errorf("dry run must not move shards, got %v", stub.moves)
+	}
+
+	if len(plan.Moves) != 1 || plan.Moves[0].ShardID != 1 {
+		t.Fatalf("expected shard 1 to move off the loaded peer, got %v", plan.Moves)
+	}
+
+	if plan.Before[1] != 1000 || plan.After[1] != 900 {
+		t.Errorf("unexpected load of peer 1: %d -> %d", plan.Before[1], plan.After[1])
+	}
+
+	if !strings.Contains(output.String(), "load in points:") {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestRebalancerCountsSkippedCollections(t *testing.T) {
+	client := newStubClient(t, serveCluster([]uint64{1, 2, 3}, []string{"busy", "docs"}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/collections/busy/cluster":
+			// Peer 1 carries two replicas of a collection with a running transfer
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":2,` +
+				`"local_shards":[{"shard_id":0,"points_count":10,"state":"Active"},{"shard_id":1,"points_count":10,"state":"Active"}],` +
+				`"remote_shards":[{"shard_id":1,"peer_id":3,"state":"Partial"}],` +
+				`"shard_transfers":[{"shard_id":1,"from":1,"to":3,"sync":true}]}}`))
+		case "/collections/docs/cluster":
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":2,"local_shards":[],` +
+				`"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Active"},{"shard_id":1,"peer_id":2,"state":"Active"}],"shard_transfers":[]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	plan, err := qdrant.NewRebalancer(client, qdrant.RebalanceOptions{DryRun: true}).Plan(context.Background())
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	if len(plan.Skipped) != 1 || plan.Skipped[0] != "busy" {
+		t.Errorf("expected busy to be skipped, got %v", plan.Skipped)
+	}
+	if plan.Before[1] != 2 || plan.Before[3] != 1 {
+		t.Errorf("expected the skipped replicas to count, got %v", plan.Before)
+	}
+	for _, move := range plan.Moves {
+		if move.Collection == "busy" || move.ToPeerID == 1 {
+			t.Errorf("unexpected move %s", move)
+		}
+	}
+}
+
+func TestRebalancerWaitsForMoveToShowUp(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+			return
+		}
+
+		// The first polls come before the transfer is registered
+		polls++
+		remote, transfers := "", ""
+		switch {
+		case polls == 3:
+			remote = `{"shard_id":0,"peer_id":2,"state":"Partial"}`
+			transfers = `{"shard_id":0,"from":1,"to":2,"sync":false,"comment":"Transferring records (100/400), started 1s ago"}`
+		case polls > 3:
+			remote = `{"shard_id":0,"peer_id":2,"state":"Active"}`
+		}
+		w.Write([]byte(`{"status":"ok","time":0,"result":{"peer_id":1,"shard_count":1,"local_shards":[],"remote_shards":[` + remote + `],"shard_transfers":[` + transfers + `]}}`))
+	})
+
+	var output bytes.Buffer
+	err := qdrant.NewRebalancer(client, qdrant.RebalanceOptions{PollInterval: time.Millisecond, Output: &output}).Execute(context.Background(), &qdrant.RebalancePlan{
+		Moves: []qdrant.ShardMove{{Collection: "docs", ShardID: 0, FromPeerID: 1, ToPeerID: 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to execute move: %v", err)
+	}
+	if polls != 4 {
+		t.Errorf("expected 4 polls, got %d", polls)
+	}
+	if !strings.Contains(output.String(), `progress: move shard 0 of "docs" from peer 1 to peer 2 (0 points): 100/400`) {
+		t.Errorf("expected the transfer progress in the output:\n%s", output.String())
+	}
+}
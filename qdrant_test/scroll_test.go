@@ -0,0 +1,174 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// orderedScrollStub serves points ordered by descending timestamp, like the server does
+// for an order_by scroll: start_from is inclusive and has_id conditions in must_not are honoured
+func orderedScrollStub(t *testing.T, timestamps []int, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		var request struct {
+			Limit   int `json:"limit"`
+			OrderBy struct {
+				Key       string   `json:"key"`
+				Direction string   `json:"direction"`
+				StartFrom *float64 `json:"start_from"`
+			} `json:"order_by"`
+			Filter struct {
+				MustNot []struct {
+					HasID []uint64 `json:"has_id"`
+				} `json:"must_not"`
+			} `json:"filter"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		if request.OrderBy.Key != "created_at" || request.OrderBy.Direction != "desc" {
+			t.Errorf("unexpected order_by %+v", request.OrderBy)
+		}
+
+		excluded := make(map[uint64]bool)
+		for _, condition := range request.Filter.MustNot {
+			for _, id := range condition.HasID {
+				excluded[id] = true
+			}
+		}
+
+		// timestamps are already in descending order and the ID is the index
+		var points []string
+		for id, ts := range timestamps {
+			if excluded[uint64(id)] || (request.OrderBy.StartFrom != nil && float64(ts) > *request.OrderBy.StartFrom) {
+				continue
+			}
+			if len(points) == request.Limit {
+				break
+			}
+			points = append(points, fmt.Sprintf(`{"id":%d,"order_value":%d}`, id, ts))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","time":0,"result":{"points":[` + strings.Join(points, ",") + `],"next_page_offset":null}}`))
+	}
+}
+
+func TestScrollAllOrderByWithTies(t *testing.T) {
+	timestamps := []int{50, 40, 40, 40, 30, 30, 20, 10}
+
+	var requests int
+	client := newStubClient(t, orderedScrollStub(t, timestamps, &requests))
+
+	it := client.ScrollAll("docs", &qdrant.ScrollRequest{
+		Limit:   2,
+		OrderBy: &qdrant.OrderBy{Key: "created_at", Direction: qdrant.DirectionDesc},
+	})
+
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Record().ID.String())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("scroll failed: %v", err)
+	}
+
+	want := "0,1,2,3,4,5,6,7"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("expected every point exactly once in order %s, got %s", want, got)
+	}
+
+	if requests != 5 {
+		t.Errorf("expected 5 page requests, got %d", requests)
+	}
+}
+
+func TestScrollAllByID(t *testing.T) {
+	pages := []string{
+		`{"points":[{"id":1},{"id":2}],"next_page_offset":3}`,
+		`{"points":[{"id":3}],"next_page_offset":null}`,
+	}
+
+	var offsets []string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&request)
+		offsets = append(offsets, string(request["offset"]))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","time":0,"result":` + pages[len(offsets)-1] + `}`))
+	})
+
+	it := client.ScrollAll("docs", &qdrant.ScrollRequest{Limit: 2})
+
+	var count int
+	for it.Next(context.Background()) {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("scroll failed: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3 points, got %d", count)
+	}
+
+	if strings.Join(offsets, ",") != ",3" {
+		t.Errorf("expected offsets to follow next_page_offset, got %v", offsets)
+	}
+}
+
+func TestScrollAllNilRequest(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"points":[{"id":1}],"next_page_offset":null}}`))
+
+	it := client.ScrollAll("docs", nil)
+
+	var count int
+	for it.Next(context.Background()) {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("scroll failed: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 point, got %d", count)
+	}
+
+	if requests := recorder.all(); len(requests) != 1 || !strings.HasPrefix(requests[0], "POST /collections/docs/points/scroll ") {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestQueryOrderByAndSampleJSON(t *testing.T) {
+	got, err := json.Marshal(qdrant.QueryRequest{
+		Query: qdrant.NewQueryOrderBy(qdrant.OrderBy{Key: "created_at", Direction: qdrant.DirectionDesc, StartFrom: 100}),
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	want := `{"query":{"order_by":{"key":"created_at","direction":"desc","start_from":100}},"limit":10}`
+	if string(got) != want {
+		t.Errorf("unexpected JSON:\n got: %s\nwant: %s", got, want)
+	}
+
+	got, err = json.Marshal(qdrant.NewQuerySample(qdrant.SampleRandom))
+	if err != nil {
+		t.Fatalf("failed to marshal query: %v", err)
+	}
+
+	if string(got) != `{"sample":"random"}` {
+		t.Errorf("unexpected JSON: %s", got)
+	}
+}
@@ -0,0 +1,140 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// newSearchParams returns search params with every option set
+func newSearchParams() *qdrant.SearchParams {
+	hnswEf := uint64(128)
+	exact := false
+	indexedOnly := true
+	ignore := false
+	rescore := true
+	oversampling := 2.5
+	enable := true
+	maxSelectivity := 0.4
+
+	return &qdrant.SearchParams{
+		HnswEf:      &hnswEf,
+		Exact:       &exact,
+		IndexedOnly: &indexedOnly,
+		Quantization: &qdrant.QuantizationSearchParams{
+			Ignore:       &ignore,
+			Rescore:      &rescore,
+			Oversampling: &oversampling,
+		},
+		Acorn: &qdrant.AcornSearchParams{
+			Enable:         &enable,
+			MaxSelectivity: &maxSelectivity,
+		},
+	}
+}
+
+func TestSearchParamsMarshal(t *testing.T) {
+	bodyBytes, err := json.Marshal(newSearchParams())
+	if err != nil {
+		t.Fatalf("failed to marshal search params: %v", err)
+	}
+
+	want := `{"hnsw_ef":128,"exact":false,"quantization":{"ignore":false,"rescore":true,"oversampling":2.5},"indexed_only":true,"acorn":{"enable":true,"max_selectivity":0.4}}`
+	if string(bodyBytes) != want {
+		t.Errorf("unexpected search params:\n got: %s\nwant: %s", bodyBytes, want)
+	}
+
+	bodyBytes, err = json.Marshal(&qdrant.SearchParams{})
+	if err != nil {
+		t.Fatalf("failed to marshal search params: %v", err)
+	}
+	if string(bodyBytes) != `{}` {
+		t.Errorf("expected unset params to be omitted, got %s", bodyBytes)
+	}
+}
+
+func TestBatchRequestShapes(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":[]}`))
+
+	ctx := context.Background()
+	exact := true
+	params := &qdrant.SearchParams{Exact: &exact, Acorn: &qdrant.AcornSearchParams{Enable: &exact}}
+
+	if _, err := client.SearchBatch(ctx, "docs", &qdrant.SearchBatchRequest{
+		Searches: []qdrant.SearchRequest{
+			{Vector: qdrant.NamedVector{Dense: []float32{1, 0}}, Params: params, Limit: 3},
+			{
+				Vector:      qdrant.NamedVector{Name: "text", Sparse: &qdrant.SparseVector{Indices: []uint32{4}, Values: []float32{0.5}}},
+				Limit:       2,
+				WithPayload: qdrant.NewWithPayloadInclude("title"),
+			},
+		},
+		Consistency: qdrant.NewReadConsistency(qdrant.ReadConsistencyMajority),
+	}); err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+
+	if _, err := client.RecommendBatch(ctx, "docs", &qdrant.RecommendBatchRequest{
+		Searches: []qdrant.RecommendRequest{
+			{
+				Positive: []qdrant.VectorInput{*qdrant.NewVectorInputID(qdrant.NewIDNum(1))},
+				Negative: []qdrant.VectorInput{*qdrant.NewVectorInputDense([]float32{0, 1})},
+				Strategy: qdrant.RecommendStrategyBestScore,
+				Params:   params,
+				Limit:    5,
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to recommend: %v", err)
+	}
+
+	if _, err := client.QueryBatch(ctx, "docs", &qdrant.QueryBatchRequest{
+		Searches: []qdrant.QueryRequest{
+			{Query: qdrant.NewQueryNearest(qdrant.NewVectorInputID(qdrant.NewIDUUID("a"))), Params: params, Limit: 4},
+			{
+				Prefetch: []qdrant.Prefetch{{Query: qdrant.NewQueryNearest(qdrant.NewVectorInputDense([]float32{1, 1})), Params: params, Limit: 20}},
+				Query:    qdrant.NewQueryFusion(qdrant.FusionRRF),
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`POST /collections/docs/points/search/batch?consistency=majority {"searches":[`+
+			`{"vector":[1,0],"params":{"exact":true,"acorn":{"enable":true}},"limit":3},`+
+			`{"vector":{"name":"text","vector":{"indices":[4],"values":[0.5]}},"limit":2,"with_payload":{"include":["title"]}}]}`,
+		`POST /collections/docs/points/recommend/batch {"searches":[`+
+			`{"positive":[1],"negative":[[0,1]],"strategy":"best_score","params":{"exact":true,"acorn":{"enable":true}},"limit":5}]}`,
+		`POST /collections/docs/points/query/batch {"searches":[`+
+			`{"query":{"nearest":"a"},"params":{"exact":true,"acorn":{"enable":true}},"limit":4},`+
+			`{"prefetch":[{"query":{"nearest":[1,1]},"params":{"exact":true,"acorn":{"enable":true}},"limit":20}],"query":{"fusion":"rrf"}}]}`,
+	)
+}
+
+func TestBatchRequestsAreValidated(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	unnamedSparse := qdrant.NamedVector{Sparse: &qdrant.SparseVector{Indices: []uint32{1}, Values: []float32{1}}}
+	if _, err := client.SearchBatch(ctx, "docs", &qdrant.SearchBatchRequest{
+		Searches: []qdrant.SearchRequest{{Vector: unnamedSparse, Limit: 1}},
+	}); err == nil {
+		t.Error("expected an error for an unnamed sparse search vector")
+	}
+
+	if _, err := client.RecommendBatch(ctx, "docs", &qdrant.RecommendBatchRequest{
+		Searches: []qdrant.RecommendRequest{{Positive: []qdrant.VectorInput{*qdrant.NewVectorInputMulti([][]float32{{1}, {1, 2}})}, Limit: 1}},
+	}); err == nil {
+		t.Error("expected an error for a ragged multivector example")
+	}
+
+	if _, err := client.QueryBatch(ctx, "docs", &qdrant.QueryBatchRequest{
+		Searches: []qdrant.QueryRequest{{Limit: 1, WithPayload: &qdrant.PayloadSelector{Include: []string{"a"}, Exclude: []string{"b"}}}},
+	}); err == nil {
+		t.Error("expected an error for a payload selector with include and exclude")
+	}
+}
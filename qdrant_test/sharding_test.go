@@ -0,0 +1,75 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestShardKeyLifecycle(t *testing.T) {
+	var requests []string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(bodyBytes))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/collections/docs/points" {
+			w.Write([]byte(`{"status":"ok","time":0,"result":{"operation_id":1,"status":"acknowledged"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+	})
+
+	ctx := context.Background()
+	shards := uint32(2)
+	if _, err := client.CreateShardKey(ctx, "docs", &qdrant.CreateShardKeyRequest{
+		ShardKey:     qdrant.NewShardKeyKeyword("tenant_a"),
+		ShardsNumber: &shards,
+		Placement:    []uint64{11, 12},
+		InitialState: qdrant.ReplicaStatePartial,
+	}); err != nil {
+		t.Fatalf("failed to create shard key: %v", err)
+	}
+
+	if _, err := client.UpsertPoints(ctx, "docs", &qdrant.UpsertPointsRequest{
+		Points: []qdrant.PointStruct{
+			{ID: qdrant.NewIDNum(1), Vector: qdrant.NewVectorsUnnamed(qdrant.NewVectorDense([]float32{1, 0}))},
+		},
+		ShardKey: qdrant.NewShardKeySelector(qdrant.NewShardKeyKeyword("tenant_a")),
+	}); err != nil {
+		t.Fatalf("failed to upsert points: %v", err)
+	}
+
+	if _, err := client.DeleteShardKey(ctx, "docs", qdrant.NewShardKeyNum(7)); err != nil {
+		t.Fatalf("failed to delete shard key: %v", err)
+	}
+
+	want := []string{
+		`PUT /collections/docs/shards {"shard_key":"tenant_a","shards_number":2,"placement":[11,12],"initial_state":"Partial"}`,
+		`PUT /collections/docs/points {"points":[{"id":1,"vector":[1,0]}],"shard_key":"tenant_a"}`,
+		`POST /collections/docs/shards/delete {"shard_key":7}`,
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected %d requests, got %v", len(want), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("unexpected request %d:\n got: %s\nwant: %s", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestShardKeyUnmarshal(t *testing.T) {
+	var keys []qdrant.ShardKey
+	if err := json.Unmarshal([]byte(`["tenant_a", 42]`), &keys); err != nil {
+		t.Fatalf("failed to unmarshal shard keys: %v", err)
+	}
+
+	if keys[0] != qdrant.NewShardKeyKeyword("tenant_a") || keys[1] != qdrant.NewShardKeyNum(42) {
+		t.Errorf("unexpected shard keys %+v", keys)
+	}
+}
@@ -0,0 +1,86 @@
+package qdrant_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestSparseVectorFromMap(t *testing.T) {
+	v := qdrant.NewSparseVectorFromMap(map[uint32]float32{7: 0.7, 1: 0.1, 3: 0.3})
+
+	if !reflect.DeepEqual(v.Indices, []uint32{1, 3, 7}) {
+		t.Errorf("unexpected indices: %v", v.Indices)
+	}
+
+	if !reflect.DeepEqual(v.Values, []float32{0.1, 0.3, 0.7}) {
+		t.Errorf("unexpected values: %v", v.Values)
+	}
+
+	if err := v.Validate(); err != nil {
+		t.Errorf("expected valid vector, got %v", err)
+	}
+}
+
+func TestSparseVectorSort(t *testing.T) {
+	v := &qdrant.SparseVector{
+		Indices: []uint32{9, 2, 5},
+		Values:  []float32{0.9, 0.2, 0.5},
+	}
+	v.Sort()
+
+	if !reflect.DeepEqual(v.Indices, []uint32{2, 5, 9}) {
+		t.Errorf("unexpected indices: %v", v.Indices)
+	}
+
+	if !reflect.DeepEqual(v.Values, []float32{0.2, 0.5, 0.9}) {
+		t.Errorf("unexpected values: %v", v.Values)
+	}
+}
+
+func TestSparseVectorToMapIgnoresUnpairedEntries(t *testing.T) {
+	v := &qdrant.SparseVector{
+		Indices: []uint32{1, 4, 7},
+		Values:  []float32{0.1, 0.4},
+	}
+
+	if m := v.ToMap(); !reflect.DeepEqual(m, map[uint32]float32{1: 0.1, 4: 0.4}) {
+		t.Errorf("unexpected map: %v", m)
+	}
+}
+
+func TestSparseVectorValidate(t *testing.T) {
+	mismatched := &qdrant.SparseVector{Indices: []uint32{1, 2}, Values: []float32{0.1}}
+	if err := mismatched.Validate(); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+
+	duplicated := &qdrant.SparseVector{Indices: []uint32{1, 1}, Values: []float32{0.1, 0.2}}
+	if err := duplicated.Validate(); err == nil {
+		t.Error("expected error for duplicate indices")
+	}
+}
+
+func TestQueryRejectsMalformedSparseVector(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+
+	ctx := context.Background()
+	_, err := client.Query(ctx, "test_collection", &qdrant.QueryRequest{
+		Query: qdrant.NewQueryNearest(qdrant.NewVectorInputSparse(&qdrant.SparseVector{
+			Indices: []uint32{1, 1},
+			Values:  []float32{0.1, 0.2},
+		})),
+		Using: "text",
+		Limit: 10,
+	})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if !strings.Contains(err.Error(), "validating request") {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package qdrant_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// newStubConfig starts a server with the given handler and returns a config pointing to it
+func newStubConfig(t *testing.T, handler http.HandlerFunc) *qdrant.Config {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server url: %v", err)
+	}
+
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("failed to parse server port: %v", err)
+	}
+
+	return &qdrant.Config{
+		Host: u.Hostname(),
+		Port: port,
+	}
+}
+
+// newClientFromConfig creates a client that is closed when the test ends
+func newClientFromConfig(t *testing.T, config *qdrant.Config) *qdrant.Client {
+	t.Helper()
+
+	client, err := qdrant.NewClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// newStubClient starts a server with the given handler and returns a client connected to it
+func newStubClient(t *testing.T, handler http.HandlerFunc) *qdrant.Client {
+	t.Helper()
+
+	return newClientFromConfig(t, newStubConfig(t, handler))
+}
+
+// rejectRequests returns a handler that fails the test on any request, for tests that
+// expect validation to stop the request before it is sent
+func rejectRequests(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.RequestURI())
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// serveCluster answers /cluster for a cluster of the given peers, as seen from the first
+// one, and /collections with the given collections; every other request goes to next
+func serveCluster(peers []uint64, collections []string, next http.HandlerFunc) http.HandlerFunc {
+	var peerEntries, collectionEntries []string
+	for _, peerID := range peers {
+		peerEntries = append(peerEntries, fmt.Sprintf(`"%d":{"uri":"http://peer-%d:6335"}`, peerID, peerID))
+	}
+	for _, collection := range collections {
+		collectionEntries = append(collectionEntries, fmt.Sprintf(`{"name":%q}`, collection))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cluster":
+			fmt.Fprintf(w, `{"status":"ok","time":0,"result":{"peer_id":%d,"peers":{%s}}}`, peers[0], strings.Join(peerEntries, ","))
+		case "/collections":
+			fmt.Fprintf(w, `{"status":"ok","time":0,"result":{"collections":[%s]}}`, strings.Join(collectionEntries, ","))
+		default:
+			next(w, r)
+		}
+	}
+}
+
+// requestRecorder records the requests a stub server receives as "METHOD uri body"
+type requestRecorder struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+// handler records every request and answers with the given response
+func (r *requestRecorder) handler(response string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		bodyBytes, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, req.Method+" "+req.URL.RequestURI()+" "+string(bodyBytes))
+		r.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}
+}
+
+// all returns the recorded requests
+func (r *requestRecorder) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.requests...)
+}
+
+// expectRequests checks the recorded requests against the expected ones
+func (r *requestRecorder) expectRequests(t *testing.T, want ...string) {
+	t.Helper()
+
+	got := r.all()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d requests, got %d:\n%s", len(want), len(got), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected request %d:\n got: %s\nwant: %s", i, got[i], want[i])
+		}
+	}
+}
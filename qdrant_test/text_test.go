@@ -0,0 +1,208 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestTextMatchConditions(t *testing.T) {
+	filter := &qdrant.Filter{
+		Must: []qdrant.Condition{
+			qdrant.NewMatchText("body", "vector search"),
+			qdrant.NewMatchPhrase("title", "hello world"),
+			qdrant.NewMatchTextAny("tags", "go rust"),
+		},
+	}
+
+	bodyBytes, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("failed to marshal filter: %v", err)
+	}
+
+	want := `{"must":[` +
+		`{"key":"body","match":{"text":"vector search"}},` +
+		`{"key":"title","match":{"phrase":"hello world"}},` +
+		`{"key":"tags","match":{"text_any":"go rust"}}]}`
+	if string(bodyBytes) != want {
+		t.Errorf("unexpected filter:\n got: %s\nwant: %s", bodyBytes, want)
+	}
+}
+
+func TestEmptyTextMatchIsRejected(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	for _, condition := range []qdrant.Condition{
+		qdrant.NewMatchText("body", ""),
+		qdrant.NewMatchPhrase("body", ""),
+		qdrant.NewMatchTextAny("body", "  "),
+	} {
+		_, err := client.CountPoints(ctx, "docs", &qdrant.CountPointsRequest{
+			Filter: &qdrant.Filter{Must: []qdrant.Condition{condition}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "must condition 0: match") {
+			t.Errorf("expected an empty text query error for %+v, got %v", condition.Match, err)
+		}
+	}
+}
+
+func TestStopwordsConfigJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *qdrant.StopwordsConfig
+		json   string
+	}{
+		{
+			name:   "single language",
+			config: qdrant.NewStopwordsLanguage("english"),
+			json:   `"english"`,
+		},
+		{
+			name:   "custom words",
+			config: qdrant.NewStopwordsCustom("foo", "bar"),
+			json:   `{"custom":["foo","bar"]}`,
+		},
+		{
+			name:   "languages and custom words",
+			config: &qdrant.StopwordsConfig{Languages: []string{"english", "german"}, Custom: []string{"foo"}},
+			json:   `{"languages":["english","german"],"custom":["foo"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.config)
+			if err != nil {
+				t.Fatalf("failed to marshal stopwords: %v", err)
+			}
+			if string(bodyBytes) != tt.json {
+				t.Errorf("unexpected stopwords:\n got: %s\nwant: %s", bodyBytes, tt.json)
+			}
+
+			var decoded qdrant.StopwordsConfig
+			if err := json.Unmarshal(bodyBytes, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal stopwords: %v", err)
+			}
+			if !reflect.DeepEqual(&decoded, tt.config) {
+				t.Errorf("round trip changed stopwords: %+v", decoded)
+			}
+		})
+	}
+
+	var decoded qdrant.StopwordsConfig
+	if err := json.Unmarshal([]byte(`42`), &decoded); err == nil {
+		t.Error("expected an error for a numeric stopwords config")
+	}
+}
+
+func TestFieldSchemaJSON(t *testing.T) {
+	minTokenLen := uint64(2)
+	lowercase := true
+	asciiFolding := true
+	phraseMatching := true
+
+	text := qdrant.NewTextFieldSchema(qdrant.TextIndexParams{
+		Tokenizer:      qdrant.TokenizerMultilingual,
+		MinTokenLen:    &minTokenLen,
+		Lowercase:      &lowercase,
+		ASCIIFolding:   &asciiFolding,
+		Stemmer:        qdrant.NewSnowballStemmer("english"),
+		Stopwords:      qdrant.NewStopwordsLanguage("english"),
+		PhraseMatching: &phraseMatching,
+	})
+
+	tests := []struct {
+		name   string
+		schema *qdrant.FieldSchema
+		json   string
+	}{
+		{
+			name:   "plain type",
+			schema: qdrant.NewFieldSchema(qdrant.FieldTypeKeyword),
+			json:   `"keyword"`,
+		},
+		{
+			name:   "text params",
+			schema: text,
+			json: `{"type":"text","tokenizer":"multilingual","min_token_len":2,"lowercase":true,"ascii_folding":true,` +
+				`"stemmer":{"type":"snowball","language":"english"},"stopwords":"english","phrase_matching":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.schema)
+			if err != nil {
+				t.Fatalf("failed to marshal schema: %v", err)
+			}
+			if string(bodyBytes) != tt.json {
+				t.Errorf("unexpected schema:\n got: %s\nwant: %s", bodyBytes, tt.json)
+			}
+
+			var decoded qdrant.FieldSchema
+			if err := json.Unmarshal(bodyBytes, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal schema: %v", err)
+			}
+			if !reflect.DeepEqual(&decoded, tt.schema) {
+				t.Errorf("round trip changed schema: %+v", decoded)
+			}
+		})
+	}
+
+	var decoded qdrant.FieldSchema
+	if err := json.Unmarshal([]byte(`{"type":"keyword","is_tenant":true}`), &decoded); err != nil || decoded.Type != qdrant.FieldTypeKeyword || decoded.Text != nil {
+		t.Errorf("unexpected keyword params schema %+v: %v", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`{"tokenizer":"word"}`), &decoded); err == nil {
+		t.Error("expected an error for a schema object without a type")
+	}
+}
+
+func TestCreateAndDeleteFieldIndex(t *testing.T) {
+	recorder := &requestRecorder{}
+	client := newStubClient(t, recorder.handler(`{"status":"ok","time":0,"result":{"operation_id":1,"status":"completed"}}`))
+
+	ctx := context.Background()
+	if _, err := client.CreateFieldIndex(ctx, "docs", &qdrant.CreateFieldIndexRequest{
+		FieldName:    "body",
+		FieldSchema:  qdrant.NewTextFieldSchema(qdrant.TextIndexParams{Tokenizer: qdrant.TokenizerWord, Stopwords: qdrant.NewStopwordsCustom("a")}),
+		WriteOptions: qdrant.WriteOptions{Wait: true},
+	}); err != nil {
+		t.Fatalf("failed to create field index: %v", err)
+	}
+
+	if _, err := client.DeleteFieldIndex(ctx, "docs", "body", qdrant.WriteOptions{Ordering: qdrant.WriteOrderingStrong}); err != nil {
+		t.Fatalf("failed to delete field index: %v", err)
+	}
+
+	recorder.expectRequests(t,
+		`PUT /collections/docs/index?wait=true {"field_name":"body","field_schema":{"type":"text","tokenizer":"word","stopwords":{"custom":["a"]}}}`,
+		`DELETE /collections/docs/index/body?ordering=strong `,
+	)
+}
+
+func TestTextIndexParamsAreValidated(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+	ctx := context.Background()
+
+	minTokenLen := uint64(5)
+	maxTokenLen := uint64(2)
+	for _, params := range []qdrant.TextIndexParams{
+		{Tokenizer: "ngram"},
+		{MinTokenLen: &minTokenLen, MaxTokenLen: &maxTokenLen},
+		{Stemmer: &qdrant.StemmerParams{Type: "snowball"}},
+		{Stopwords: &qdrant.StopwordsConfig{}},
+	} {
+		if _, err := client.CreateFieldIndex(ctx, "docs", &qdrant.CreateFieldIndexRequest{
+			FieldName:   "body",
+			FieldSchema: qdrant.NewTextFieldSchema(params),
+		}); err == nil || !strings.Contains(err.Error(), "text index") {
+			t.Errorf("expected a text index error for %+v, got %v", params, err)
+		}
+	}
+}
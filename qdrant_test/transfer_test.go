@@ -0,0 +1,203 @@
+package qdrant_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+// transferStub serves the given collection cluster infos in turn, repeating the last one,
+// and records cluster setup requests
+func transferStub(t *testing.T, infos []string, setups *[]string) http.HandlerFunc {
+	var mu sync.Mutex
+	polls := 0
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			bodyBytes, _ := io.ReadAll(r.Body)
+			*setups = append(*setups, string(bodyBytes))
+			w.Write([]byte(`{"status":"ok","time":0,"result":true}`))
+			return
+		}
+
+		info := infos[min(polls, len(infos)-1)]
+		polls++
+		w.Write([]byte(`{"status":"ok","time":0,"result":` + info + `}`))
+	}
+}
+
+func TestWaitForTransferFinished(t *testing.T) {
+	running := func(comment string) string {
+		return `{"peer_id":1,"shard_count":1,"local_shards":[{"shard_id":0,"state":"Active"}],` +
+			`"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Partial"}],` +
+			`"shard_transfers":[{"shard_id":0,"from":1,"to":2,"sync":false,"method":"stream_records","comment":"` + comment + `"}]}`
+	}
+	infos := []string{
+		running("Transferring records (100/400), started 1s ago"),
+		running("Transferring records (300/400), started 2s ago"),
+		`{"peer_id":1,"shard_count":1,"local_shards":[],"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Active"}],"shard_transfers":[]}`,
+	}
+
+	var setups []string
+	client := newStubClient(t, transferStub(t, infos, &setups))
+
+	var fractions []float64
+	result, err := client.WaitForTransfer(context.Background(), "docs", 0, 1, 2, &qdrant.WaitForTransferOptions{
+		PollInterval: time.Millisecond,
+		OnProgress: func(progress qdrant.TransferProgress) {
+			fractions = append(fractions, progress.Fraction())
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to wait for transfer: %v", err)
+	}
+
+	if result.Outcome != qdrant.TransferFinished {
+		t.Errorf("expected finished transfer, got %s", result.Outcome)
+	}
+
+	if len(fractions) != 2 || fractions[0] != 0.25 || fractions[1] != 0.75 {
+		t.Errorf("unexpected progress %v", fractions)
+	}
+}
+
+func TestWaitForTransferFailed(t *testing.T) {
+	infos := []string{
+		`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Dead"}],"shard_transfers":[]}`,
+	}
+
+	var setups []string
+	client := newStubClient(t, transferStub(t, infos, &setups))
+
+	result, err := client.WaitForTransfer(context.Background(), "docs", 0, 1, 2, nil)
+	if err != nil {
+		t.Fatalf("failed to wait for transfer: %v", err)
+	}
+
+	if result.Outcome != qdrant.TransferFailed || result.State != qdrant.ReplicaStateDead {
+		t.Errorf("expected failed transfer to a dead replica, got %+v", result)
+	}
+}
+
+func TestWaitForTransferAbortOnDeadline(t *testing.T) {
+	infos := []string{
+		`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Partial"}],` +
+			`"shard_transfers":[{"shard_id":0,"from":1,"to":2,"sync":true}]}`,
+	}
+
+	var setups []string
+	client := newStubClient(t, transferStub(t, infos, &setups))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	result, err := client.WaitForTransfer(ctx, "docs", 0, 1, 2, &qdrant.WaitForTransferOptions{
+		PollInterval:    time.Millisecond,
+		AbortOnDeadline: true,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+
+	if result == nil || result.Outcome != qdrant.TransferAborted {
+		t.Fatalf("expected aborted transfer, got %+v", result)
+	}
+
+	if len(setups) != 1 || !strings.Contains(setups[0], `"abort_transfer":{"shard_id":0,"from_peer_id":1,"to_peer_id":2}`) {
+		t.Errorf("expected one abort request, got %v", setups)
+	}
+}
+
+func TestWaitForTransferAbortedWhenDestinationGone(t *testing.T) {
+	infos := []string{
+		`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[{"shard_id":0,"peer_id":2,"state":"Partial"}],` +
+			`"shard_transfers":[{"shard_id":0,"from":1,"to":2,"sync":true}]}`,
+		`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[],"shard_transfers":[]}`,
+	}
+
+	var setups []string
+	client := newStubClient(t, transferStub(t, infos, &setups))
+
+	result, err := client.WaitForTransfer(context.Background(), "docs", 0, 1, 2, &qdrant.WaitForTransferOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to wait for transfer: %v", err)
+	}
+
+	if result.Outcome != qdrant.TransferAborted || result.State != "" {
+		t.Errorf("expected aborted transfer without a destination replica, got %+v", result)
+	}
+}
+
+func TestWaitForTransferStalled(t *testing.T) {
+	for _, state := range []qdrant.ReplicaState{qdrant.ReplicaStatePartial, qdrant.ReplicaStateRecovery} {
+		t.Run(string(state), func(t *testing.T) {
+			infos := []string{
+				`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[{"shard_id":0,"peer_id":2,"state":"` + string(state) + `"}],"shard_transfers":[]}`,
+			}
+
+			var polls int
+			var setups []string
+			stub := transferStub(t, infos, &setups)
+			client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				stub(w, r)
+			})
+
+			result, err := client.WaitForTransfer(context.Background(), "docs", 0, 1, 2, &qdrant.WaitForTransferOptions{
+				PollInterval: time.Millisecond,
+				SettlePolls:  3,
+			})
+			if err != nil {
+				t.Fatalf("failed to wait for transfer: %v", err)
+			}
+
+			if result.Outcome != qdrant.TransferStalled || result.State != state {
+				t.Errorf("expected stalled transfer in state %s, got %+v", state, result)
+			}
+			if polls != 3 {
+				t.Errorf("expected 3 polls, got %d", polls)
+			}
+		})
+	}
+}
+
+func TestWaitForTransferStartPolls(t *testing.T) {
+	infos := []string{
+		`{"peer_id":1,"local_shards":[{"shard_id":0,"state":"Active"}],"remote_shards":[],"shard_transfers":[]}`,
+	}
+
+	var setups []string
+	client := newStubClient(t, transferStub(t, infos, &setups))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	result, err := client.WaitForTransfer(ctx, "docs", 0, 1, 2, &qdrant.WaitForTransferOptions{
+		PollInterval: 10 * time.Millisecond,
+		StartPolls:   2,
+	})
+	if err != nil {
+		t.Fatalf("failed to wait for transfer: %v", err)
+	}
+
+	if result.Outcome != qdrant.TransferAborted {
+		t.Errorf("expected a transfer that never shows up to be aborted, got %+v", result)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected two polls to be tolerated, returned after %s", elapsed)
+	}
+}
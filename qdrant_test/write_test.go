@@ -0,0 +1,85 @@
+package qdrant_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/al-masood/qdrant-http-go-client/qdrant"
+)
+
+func TestMutationWriteOptions(t *testing.T) {
+	var method, path, query, body string
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		method, path, query, body = r.Method, r.URL.Path, r.URL.RawQuery, string(bodyBytes)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","time":0,"result":{"operation_id":42,"status":"completed"}}`))
+	})
+
+	ctx := context.Background()
+	response, err := client.DeletePoints(ctx, "docs", &qdrant.DeletePointsRequest{
+		Points:       []qdrant.PointID{qdrant.NewIDNum(1), qdrant.NewIDUUID("5c56c793-69f3-4fbf-87e6-c4bf54c28c26")},
+		WriteOptions: qdrant.WriteOptions{Wait: true, Ordering: qdrant.WriteOrderingStrong},
+	})
+	if err != nil {
+		t.Fatalf("failed to delete points: %v", err)
+	}
+
+	if method != http.MethodPost || path != "/collections/docs/points/delete" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+
+	if query != "ordering=strong&wait=true" {
+		t.Errorf("unexpected query %q", query)
+	}
+
+	if body != `{"points":[1,"5c56c793-69f3-4fbf-87e6-c4bf54c28c26"]}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if response.Result.Status != qdrant.UpdateStatusCompleted || response.Result.OperationID == nil || *response.Result.OperationID != 42 {
+		t.Errorf("unexpected result %+v", response.Result)
+	}
+
+	_, err = client.SetPayload(ctx, "docs", &qdrant.SetPayloadRequest{
+		Payload: map[string]interface{}{"color": "red"},
+		Filter:  &qdrant.Filter{Must: []qdrant.Condition{qdrant.NewMatch("sku", "A1")}},
+	})
+	if err != nil {
+		t.Fatalf("failed to set payload: %v", err)
+	}
+
+	if method != http.MethodPost || path != "/collections/docs/points/payload" || query != "" {
+		t.Errorf("unexpected request %s %s?%s", method, path, query)
+	}
+
+	if body != `{"payload":{"color":"red"},"filter":{"must":[{"key":"sku","match":{"value":"A1"}}]}}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestMutationValidation(t *testing.T) {
+	client := newStubClient(t, rejectRequests(t))
+
+	ctx := context.Background()
+	_, err := client.ClearPayload(ctx, "docs", &qdrant.ClearPayloadRequest{
+		Points:       []qdrant.PointID{qdrant.NewIDNum(1)},
+		WriteOptions: qdrant.WriteOptions{Ordering: "eventual"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported ordering") {
+		t.Errorf("expected ordering error, got %v", err)
+	}
+
+	_, err = client.DeleteVectors(ctx, "docs", &qdrant.DeleteVectorsRequest{
+		Points: []qdrant.PointID{qdrant.NewIDNum(1)},
+		Filter: &qdrant.Filter{},
+		Vector: []string{"image"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected selector error, got %v", err)
+	}
+}